@@ -2,10 +2,23 @@ package accessory
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"time"
 )
 
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type CollectionOptType string
+
+// CollectionOpt represents an option used when creating a collection,
+// for example to create a capped or time-series collection
+type CollectionOpt struct {
+	Type  CollectionOptType
+	Value any
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // INTERFACES
 
@@ -51,7 +64,9 @@ type Conn interface {
 	Databases(context.Context) ([]Database, error)
 
 	// Perform operations within a transaction. Rollback or apply
-	// changes to the database depending on error return.
+	// changes to the database depending on error return. Operations
+	// must use the context passed to the function in order to
+	// participate in the transaction.
 	Do(context.Context, func(context.Context) error) error
 
 	// Return a filter specification
@@ -70,6 +85,16 @@ type Database interface {
 	// Return a collection object for a specific struct
 	Collection(any) Collection
 
+	// Return the names of all collections in the database
+	Collections(context.Context) ([]string, error)
+
+	// Create a collection for a specific struct with options, and return
+	// the collection. Returns ErrDuplicateEntry if the collection already exists.
+	CreateCollection(context.Context, any, ...CollectionOpt) (Collection, error)
+
+	// Drop the database and all collections within it
+	Drop(context.Context) error
+
 	// Insert documents of the same type to the database. The document key is updated
 	// if the document is writable.
 	Insert(context.Context, ...any) error
@@ -79,6 +104,17 @@ type Collection interface {
 	// Return the name of the collection
 	Name() string
 
+	// Drop the collection and all documents within it
+	Drop(context.Context) error
+
+	// Return a filter specification for the collection. In strict mode, the
+	// field names are validated against the collection documents
+	F() Filter
+
+	// Return a sort specification for the collection. In strict mode, the
+	// field names are validated against the collection documents
+	S() Sort
+
 	// Delete zero or one documents and returns the number of deleted documents (which should be
 	// zero or one. The filter argument is used to determine a document to delete. If there is more than
 	// one filter, they are ANDed together
@@ -95,6 +131,11 @@ type Collection interface {
 	// It returns ErrNotFound if no document is found
 	FindMany(context.Context, Sort, ...Filter) (Cursor, error)
 
+	// Search returns an iterable cursor of documents which match a full-text
+	// search query and filter parameters, ordered by relevance. Fields are
+	// marked as searchable with the "search" struct tag flag.
+	Search(context.Context, string, ...Filter) (Cursor, error)
+
 	// Update zero or one document with given values and return the number
 	// of documents matched and modified, neither of which should be more than one.
 	Update(context.Context, any, ...Filter) (int64, int64, error)
@@ -156,3 +197,32 @@ type Sort interface {
 	// Limit the number of documents returned
 	Limit(int64) error
 }
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	CollectionCapped       CollectionOptType = "capped"        // int64: Create a capped collection with maximum size in bytes
+	CollectionMaxDocuments CollectionOptType = "max_documents" // int64: The maximum number of documents in a capped collection
+	CollectionTimeField    CollectionOptType = "time_field"    // string: Create a time-series collection with the field which contains the date
+	CollectionMetaField    CollectionOptType = "meta_field"    // string: The field in a time-series collection which contains metadata
+	CollectionGranularity  CollectionOptType = "granularity"   // string: The granularity of a time-series collection (seconds, minutes or hours)
+	CollectionExpires      CollectionOptType = "expires"       // time.Duration: Documents are removed from the collection after this duration
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (o CollectionOpt) String() string {
+	str := "<collectionopt"
+	str += fmt.Sprintf(" type=%q", o.Type)
+	if o.Value != nil {
+		switch v := o.Value.(type) {
+		case string:
+			str += fmt.Sprintf(" value=%q", v)
+		default:
+			str += fmt.Sprint(" value=", o.Value)
+		}
+	}
+	return str + ">"
+}
@@ -60,6 +60,68 @@ func OptCollection(collection any, name string) ClientOpt {
 	}
 }
 
+// Set the default read preference. The mode is one of "primary",
+// "primaryPreferred", "secondary", "secondaryPreferred" or "nearest". When
+// maxStaleness is not zero, secondaries which lag behind the primary by more
+// than this duration are not selected for reads.
+func OptReadPreference(mode string, maxStaleness time.Duration) ClientOpt {
+	return func(conn *conn) error {
+		if conn.Client == nil {
+			if rp, err := newReadPref(mode, maxStaleness); err != nil {
+				return err
+			} else {
+				conn.readpref = rp
+			}
+		}
+		return nil
+	}
+}
+
+// Set the default read concern. The level is one of "local", "available",
+// "majority", "linearizable" or "snapshot".
+func OptReadConcern(level string) ClientOpt {
+	return func(conn *conn) error {
+		if conn.Client == nil {
+			if rc, err := newReadConcern(level); err != nil {
+				return err
+			} else {
+				conn.readconcern = rc
+			}
+		}
+		return nil
+	}
+}
+
+// Set the default write concern. The w argument is either "majority", the
+// number of members which need to acknowledge the write, or a tag set name.
+// When journal is true, writes are acknowledged once written to the on-disk
+// journal. A timeout of zero means there is no limit on waiting for
+// acknowledgement.
+func OptWriteConcern(w string, journal bool, timeout time.Duration) ClientOpt {
+	return func(conn *conn) error {
+		if conn.Client == nil {
+			if wc, err := newWriteConcern(w, journal, timeout); err != nil {
+				return err
+			} else {
+				conn.writeconcern = wc
+			}
+		}
+		return nil
+	}
+}
+
+// Set strict mode, where filters and sorts returned by a collection validate
+// field names against the fields of the collection documents, and return
+// ErrBadParameter for unknown fields
+func OptStrict(v bool) ClientOpt {
+	return func(conn *conn) error {
+		if conn.Client == nil {
+			conn.strict = v
+		}
+		return nil
+	}
+}
+
 // Set the trace function
 func OptTrace(fn trace.Func) ClientOpt {
 	return func(conn *conn) error {
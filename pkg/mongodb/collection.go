@@ -1,6 +1,8 @@
 package mongodb
 
 import (
+	"context"
+
 	// Package imports
 	trace "github.com/mutablelogic/go-accessory/pkg/trace"
 	driver "go.mongodb.org/mongo-driver/mongo"
@@ -17,6 +19,7 @@ type collection struct {
 
 	meta    *meta
 	traceFn trace.Func
+	strict  bool
 }
 
 // Ensure *collection implements the Collection interface
@@ -25,7 +28,7 @@ var _ Collection = (*collection)(nil)
 ///////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
-func NewCollection(database *driver.Database, meta *meta, fn trace.Func) *collection {
+func NewCollection(database *driver.Database, meta *meta, fn trace.Func, strict bool) *collection {
 	// Check arguments
 	if database == nil || meta == nil {
 		return nil
@@ -35,6 +38,7 @@ func NewCollection(database *driver.Database, meta *meta, fn trace.Func) *collec
 		Collection: database.Collection(meta.Name),
 		meta:       meta,
 		traceFn:    fn,
+		strict:     strict,
 	}
 }
 
@@ -48,3 +52,36 @@ func (collection *collection) Name() string {
 	}
 	return collection.Collection.Name()
 }
+
+// Return an empty filter specification. In strict mode, field names
+// are validated against the fields of the collection documents
+func (collection *collection) F() Filter {
+	if collection.strict {
+		return NewStrictFilter(collection.meta)
+	} else {
+		return NewFilter()
+	}
+}
+
+// Return an empty sort specification. In strict mode, field names
+// are validated against the fields of the collection documents
+func (collection *collection) S() Sort {
+	if collection.strict {
+		return NewStrictSort(collection.meta)
+	} else {
+		return NewSort()
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return the driver collection, with any read preference, read concern
+// or write concern overrides from the context applied
+func (collection *collection) withContext(ctx context.Context) (*driver.Collection, error) {
+	if opts := collectionOpts(ctx); opts == nil {
+		return collection.Collection, nil
+	} else {
+		return collection.Collection.Clone(opts)
+	}
+}
@@ -0,0 +1,215 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	// Packages
+	multierror "github.com/hashicorp/go-multierror"
+	trace "github.com/mutablelogic/go-accessory/pkg/trace"
+	bson "go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	options "go.mongodb.org/mongo-driver/mongo/options"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-accessory"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Error code returned when a collection already exists
+	errCodeNamespaceExists = 48
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return the names of all collections in the default database
+func (conn *conn) Collections(ctx context.Context) ([]string, error) {
+	if db := conn.Database(defaultDatabase); db == nil {
+		return nil, ErrOutOfOrder.With("Collections")
+	} else {
+		return db.Collections(ctx)
+	}
+}
+
+// Create a collection in the default database
+func (conn *conn) CreateCollection(ctx context.Context, proto any, opts ...CollectionOpt) (Collection, error) {
+	if db := conn.Database(defaultDatabase); db == nil {
+		return nil, ErrOutOfOrder.With("CreateCollection")
+	} else {
+		return db.CreateCollection(ctx, proto, opts...)
+	}
+}
+
+// Return the names of all collections in the database
+func (database *database) Collections(ctx context.Context) ([]string, error) {
+	if database == nil || database.Database == nil {
+		return nil, ErrOutOfOrder
+	}
+
+	// Trace
+	ctx, matched, _ := trace.WithCollection(c(ctx), trace.OpListCollections, database.Name(), emptyCollection)
+	defer trace.Do(ctx, database.traceFn, time.Now())
+
+	// List the collections
+	names, err := database.Database.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	} else {
+		*matched = int64(len(names))
+	}
+
+	// Return success
+	return names, nil
+}
+
+// Create a collection for a specific struct with options, and return the
+// collection. Returns ErrDuplicateEntry if the collection already exists.
+func (database *database) CreateCollection(ctx context.Context, proto any, opts ...CollectionOpt) (Collection, error) {
+	if database == nil || database.Database == nil {
+		return nil, ErrOutOfOrder
+	}
+
+	// Obtain the collection metadata
+	meta := database.metaFn(proto)
+	if meta == nil {
+		return nil, ErrBadParameter.Withf("unknown collection for document of type %q", derefType(reflect.TypeOf(proto)))
+	}
+
+	// Set the options
+	createOpts, err := createCollectionOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trace
+	ctx, _, _ = trace.WithCollection(c(ctx), trace.OpCreateCollection, database.Name(), meta.Name)
+	defer trace.Do(ctx, database.traceFn, time.Now())
+
+	// Create the collection
+	if err := database.Database.CreateCollection(ctx, meta.Name, createOpts); err != nil {
+		var cmdErr driver.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == errCodeNamespaceExists {
+			return nil, ErrDuplicateEntry.With(meta.Name)
+		} else {
+			return nil, err
+		}
+	}
+
+	// Create the full-text search index, and drop the collection on error
+	collection := NewCollection(database.Database, meta, database.traceFn, database.strict)
+	if err := createSearchIndex(ctx, collection.Collection, meta); err != nil {
+		var result error
+		result = multierror.Append(result, err)
+		if err := collection.Collection.Drop(ctx); err != nil {
+			result = multierror.Append(result, err)
+		}
+		return nil, result
+	}
+
+	// Return success
+	return collection, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return options for creating a collection
+func createCollectionOpts(opts ...CollectionOpt) (*options.CreateCollectionOptions, error) {
+	result := options.CreateCollection()
+	var timeseries *options.TimeSeriesOptions
+	for _, opt := range opts {
+		switch opt.Type {
+		case CollectionCapped:
+			if size, ok := toInt64(opt.Value); !ok || size <= 0 {
+				return nil, ErrBadParameter.With(opt)
+			} else {
+				result.SetCapped(true).SetSizeInBytes(size)
+			}
+		case CollectionMaxDocuments:
+			if max, ok := toInt64(opt.Value); !ok || max <= 0 {
+				return nil, ErrBadParameter.With(opt)
+			} else {
+				result.SetMaxDocuments(max)
+			}
+		case CollectionTimeField:
+			if field, ok := opt.Value.(string); !ok || field == "" {
+				return nil, ErrBadParameter.With(opt)
+			} else {
+				timeseries = timeSeriesOpts(timeseries).SetTimeField(field)
+			}
+		case CollectionMetaField:
+			if field, ok := opt.Value.(string); !ok || field == "" {
+				return nil, ErrBadParameter.With(opt)
+			} else {
+				timeseries = timeSeriesOpts(timeseries).SetMetaField(field)
+			}
+		case CollectionGranularity:
+			if granularity, ok := opt.Value.(string); !ok || granularity == "" {
+				return nil, ErrBadParameter.With(opt)
+			} else {
+				timeseries = timeSeriesOpts(timeseries).SetGranularity(granularity)
+			}
+		case CollectionExpires:
+			if expires, ok := opt.Value.(time.Duration); !ok || expires < time.Second {
+				return nil, ErrBadParameter.With(opt)
+			} else {
+				result.SetExpireAfterSeconds(int64(expires / time.Second))
+			}
+		default:
+			return nil, ErrBadParameter.With(opt)
+		}
+	}
+
+	// Check for capped options
+	if result.MaxDocuments != nil && (result.Capped == nil || !*result.Capped) {
+		return nil, ErrBadParameter.With("maximum number of documents requires a capped collection")
+	}
+
+	// Check for time-series options
+	if timeseries != nil {
+		if timeseries.TimeField == "" {
+			return nil, ErrBadParameter.With("time-series collection requires a time field")
+		} else if result.Capped != nil && *result.Capped {
+			return nil, ErrBadParameter.With("time-series collection cannot be capped")
+		} else {
+			result.SetTimeSeriesOptions(timeseries)
+		}
+	}
+
+	// Return success
+	return result, nil
+}
+
+// Return time-series options, creating them if necessary
+func timeSeriesOpts(opts *options.TimeSeriesOptions) *options.TimeSeriesOptions {
+	if opts == nil {
+		return options.TimeSeries()
+	} else {
+		return opts
+	}
+}
+
+// Convert an integer value into an int64
+func toInt64(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,84 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// Packages
+	mongodb "github.com/mutablelogic/go-accessory/pkg/mongodb"
+	trace "github.com/mutablelogic/go-accessory/pkg/trace"
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-accessory"
+)
+
+func Test_Collections_001(t *testing.T) {
+	assert := assert.New(t)
+
+	type Capped struct {
+		Key  string `bson:"_id,omitempty"`
+		Name string `bson:"name"`
+	}
+
+	type Series struct {
+		Key  string    `bson:"_id,omitempty"`
+		Time time.Time `bson:"time"`
+		Meta string    `bson:"meta"`
+	}
+
+	c, err := mongodb.Open(context.TODO(), uri(t), mongodb.OptTrace(func(ctx context.Context, delta time.Duration, err error) {
+		if err != nil {
+			t.Log("TRACE:", trace.DumpContextStr(ctx), "=>", err)
+		} else {
+			t.Log("TRACE:", trace.DumpContextStr(ctx), "=>", delta)
+		}
+	}))
+	assert.NoError(err)
+	defer c.Close()
+
+	// Use a new database for each test run
+	db := c.Database(t.Name() + "_" + time.Now().Format("20060102150405"))
+	assert.NotNil(db)
+	defer db.Drop(context.TODO())
+
+	t.Run("001", func(t *testing.T) {
+		collection, err := db.CreateCollection(context.TODO(), Capped{}, CollectionOpt{Type: CollectionCapped, Value: 1024 * 1024}, CollectionOpt{Type: CollectionMaxDocuments, Value: 100})
+		assert.NoError(err)
+		assert.NotNil(collection)
+		assert.Equal("Capped", collection.Name())
+	})
+
+	t.Run("002", func(t *testing.T) {
+		_, err := db.CreateCollection(context.TODO(), Capped{})
+		assert.ErrorIs(err, ErrDuplicateEntry)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		collection, err := db.CreateCollection(context.TODO(), Series{}, CollectionOpt{Type: CollectionTimeField, Value: "time"}, CollectionOpt{Type: CollectionMetaField, Value: "meta"}, CollectionOpt{Type: CollectionGranularity, Value: "minutes"})
+		assert.NoError(err)
+		assert.NotNil(collection)
+	})
+
+	t.Run("004", func(t *testing.T) {
+		names, err := db.Collections(context.TODO())
+		assert.NoError(err)
+		assert.Contains(names, "Capped")
+		assert.Contains(names, "Series")
+	})
+
+	t.Run("005", func(t *testing.T) {
+		assert.NoError(db.Collection(Series{}).Drop(context.TODO()))
+		names, err := db.Collections(context.TODO())
+		assert.NoError(err)
+		assert.Contains(names, "Capped")
+		assert.NotContains(names, "Series")
+	})
+
+	t.Run("006", func(t *testing.T) {
+		_, err := db.CreateCollection(context.TODO(), Series{}, CollectionOpt{Type: CollectionMaxDocuments, Value: 100})
+		assert.ErrorIs(err, ErrBadParameter)
+	})
+}
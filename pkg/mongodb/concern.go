@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	// Packages
+	options "go.mongodb.org/mongo-driver/mongo/options"
+	readconcern "go.mongodb.org/mongo-driver/mongo/readconcern"
+	readpref "go.mongodb.org/mongo-driver/mongo/readpref"
+	writeconcern "go.mongodb.org/mongo-driver/mongo/writeconcern"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type ctxKey uint
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	ctxReadPref     ctxKey = iota // Read preference override
+	ctxReadConcern                // Read concern override
+	ctxWriteConcern               // Write concern override
+)
+
+const (
+	// Write concern which requests acknowledgement from a majority of members
+	writeMajority = "majority"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// WithReadPreference returns a context which overrides the read preference
+// for operations which use it. The mode is one of "primary", "primaryPreferred",
+// "secondary", "secondaryPreferred" or "nearest". When maxStaleness is not zero,
+// secondaries which lag behind the primary by more than this duration are not
+// selected for reads.
+func WithReadPreference(parent context.Context, mode string, maxStaleness time.Duration) (context.Context, error) {
+	if rp, err := newReadPref(mode, maxStaleness); err != nil {
+		return nil, err
+	} else {
+		return context.WithValue(c(parent), ctxReadPref, rp), nil
+	}
+}
+
+// WithReadConcern returns a context which overrides the read concern for
+// operations which use it. The level is one of "local", "available",
+// "majority", "linearizable" or "snapshot".
+func WithReadConcern(parent context.Context, level string) (context.Context, error) {
+	if rc, err := newReadConcern(level); err != nil {
+		return nil, err
+	} else {
+		return context.WithValue(c(parent), ctxReadConcern, rc), nil
+	}
+}
+
+// WithWriteConcern returns a context which overrides the write concern for
+// operations which use it. The w argument is either "majority", the number
+// of members which need to acknowledge the write, or a tag set name. When journal
+// is true, writes are acknowledged once written to the on-disk journal. A
+// timeout of zero means there is no limit on waiting for acknowledgement.
+func WithWriteConcern(parent context.Context, w string, journal bool, timeout time.Duration) (context.Context, error) {
+	if wc, err := newWriteConcern(w, journal, timeout); err != nil {
+		return nil, err
+	} else {
+		return context.WithValue(c(parent), ctxWriteConcern, wc), nil
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return a read preference from mode and maximum staleness
+func newReadPref(mode string, maxStaleness time.Duration) (*readpref.ReadPref, error) {
+	m, err := readpref.ModeFromString(strings.TrimSpace(mode))
+	if err != nil {
+		return nil, ErrBadParameter.With(err)
+	} else if maxStaleness < 0 {
+		return nil, ErrBadParameter.With("maxStaleness")
+	}
+	var opts []readpref.Option
+	if maxStaleness > 0 {
+		opts = append(opts, readpref.WithMaxStaleness(maxStaleness))
+	}
+	if rp, err := readpref.New(m, opts...); err != nil {
+		return nil, ErrBadParameter.With(err)
+	} else {
+		return rp, nil
+	}
+}
+
+// Return a read concern from a level
+func newReadConcern(level string) (*readconcern.ReadConcern, error) {
+	switch level = strings.ToLower(strings.TrimSpace(level)); level {
+	case "local", "available", "majority", "linearizable", "snapshot":
+		return readconcern.New(readconcern.Level(level)), nil
+	default:
+		return nil, ErrBadParameter.Withf("unknown read concern %q", level)
+	}
+}
+
+// Return a write concern
+func newWriteConcern(w string, journal bool, timeout time.Duration) (*writeconcern.WriteConcern, error) {
+	var opts []writeconcern.Option
+	if timeout < 0 {
+		return nil, ErrBadParameter.With("timeout")
+	} else if timeout > 0 {
+		opts = append(opts, writeconcern.WTimeout(timeout))
+	}
+	if journal {
+		opts = append(opts, writeconcern.J(true))
+	}
+	if w = strings.TrimSpace(w); w == writeMajority {
+		opts = append(opts, writeconcern.WMajority())
+	} else if n, err := strconv.ParseUint(w, 10, 32); err == nil {
+		opts = append(opts, writeconcern.W(int(n)))
+	} else if w != "" {
+		opts = append(opts, writeconcern.WTagSet(w))
+	}
+	if wc := writeconcern.New(opts...); !wc.IsValid() {
+		return nil, ErrBadParameter.Withf("invalid write concern %q", w)
+	} else {
+		return wc, nil
+	}
+}
+
+// Return collection options for any overrides in the context, or nil
+// if there are no overrides
+func collectionOpts(ctx context.Context) *options.CollectionOptions {
+	if ctx == nil {
+		return nil
+	}
+	result, found := options.Collection(), false
+	if rp, ok := ctx.Value(ctxReadPref).(*readpref.ReadPref); ok {
+		result, found = result.SetReadPreference(rp), true
+	}
+	if rc, ok := ctx.Value(ctxReadConcern).(*readconcern.ReadConcern); ok {
+		result, found = result.SetReadConcern(rc), true
+	}
+	if wc, ok := ctx.Value(ctxWriteConcern).(*writeconcern.WriteConcern); ok {
+		result, found = result.SetWriteConcern(wc), true
+	}
+	if !found {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// Packages
+	mongodb "github.com/mutablelogic/go-accessory/pkg/mongodb"
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+func Test_Concern_001(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		Mode         string
+		MaxStaleness time.Duration
+		Err          error
+	}{
+		{"primary", 0, nil},
+		{"primaryPreferred", 0, nil},
+		{"secondary", 0, nil},
+		{"secondaryPreferred", 120 * time.Second, nil},
+		{"nearest", 120 * time.Second, nil},
+		{"primary", 120 * time.Second, ErrBadParameter},
+		{"secondary", -1, ErrBadParameter},
+		{"other", 0, ErrBadParameter},
+	}
+	for _, test := range tests {
+		ctx, err := mongodb.WithReadPreference(context.TODO(), test.Mode, test.MaxStaleness)
+		if test.Err != nil {
+			assert.ErrorIs(err, test.Err)
+			assert.Nil(ctx)
+		} else {
+			assert.NoError(err)
+			assert.NotNil(ctx)
+		}
+	}
+}
+
+func Test_Concern_002(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		Level string
+		Err   error
+	}{
+		{"local", nil},
+		{"available", nil},
+		{"majority", nil},
+		{"linearizable", nil},
+		{"snapshot", nil},
+		{"", ErrBadParameter},
+		{"other", ErrBadParameter},
+	}
+	for _, test := range tests {
+		ctx, err := mongodb.WithReadConcern(context.TODO(), test.Level)
+		if test.Err != nil {
+			assert.ErrorIs(err, test.Err)
+		} else {
+			assert.NoError(err)
+			assert.NotNil(ctx)
+		}
+	}
+}
+
+func Test_Concern_003(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		W       string
+		Journal bool
+		Timeout time.Duration
+		Err     error
+	}{
+		{"", false, 0, nil},
+		{"majority", true, 0, nil},
+		{"1", false, time.Second, nil},
+		{"0", false, 0, nil},
+		{"datacenter", false, 0, nil},
+		{"0", true, 0, ErrBadParameter},
+		{"1", false, -1, ErrBadParameter},
+	}
+	for _, test := range tests {
+		ctx, err := mongodb.WithWriteConcern(context.TODO(), test.W, test.Journal, test.Timeout)
+		if test.Err != nil {
+			assert.ErrorIs(err, test.Err, test)
+		} else {
+			assert.NoError(err, test)
+			assert.NotNil(ctx)
+		}
+	}
+}
+
+func Test_Concern_004(t *testing.T) {
+	assert := assert.New(t)
+
+	// Read from a secondary where possible
+	c, err := mongodb.Open(context.TODO(), uri(t), mongodb.OptDatabase("test"), mongodb.OptReadPreference("secondaryPreferred", 0), mongodb.OptWriteConcern("majority", false, 0))
+	assert.NoError(err)
+	defer c.Close()
+
+	// Ping with the default read preference
+	assert.NoError(c.Ping(context.TODO()))
+
+	// Ping the primary
+	ctx, err := mongodb.WithReadPreference(context.TODO(), "primary", 0)
+	assert.NoError(err)
+	assert.NoError(c.Ping(ctx))
+}
+
+func Test_Concern_005(t *testing.T) {
+	assert := assert.New(t)
+
+	type Doc struct {
+		Key  string `bson:"_id,omitempty"`
+		Name string `bson:"name"`
+	}
+
+	// Read from a secondary by default
+	c, err := mongodb.Open(context.TODO(), uri(t), mongodb.OptDatabase("test"), mongodb.OptReadPreference("secondary", 0))
+	assert.NoError(err)
+	defer c.Close()
+
+	// Transactions read from the primary
+	assert.NoError(c.Do(context.TODO(), func(ctx context.Context) error {
+		doc := Doc{Name: "Test"}
+		if err := c.Insert(ctx, &doc); err != nil {
+			return err
+		}
+		filter := c.F()
+		if err := filter.Key(doc.Key); err != nil {
+			return err
+		}
+		_, err := c.Collection(Doc{}).Find(ctx, nil, filter)
+		return err
+	}))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"sync"
 	"time"
 
 	// Packages
@@ -13,7 +14,9 @@ import (
 	bson "go.mongodb.org/mongo-driver/bson"
 	driver "go.mongodb.org/mongo-driver/mongo"
 	options "go.mongodb.org/mongo-driver/mongo/options"
+	readconcern "go.mongodb.org/mongo-driver/mongo/readconcern"
 	readpref "go.mongodb.org/mongo-driver/mongo/readpref"
+	writeconcern "go.mongodb.org/mongo-driver/mongo/writeconcern"
 
 	// Namespace imports
 	. "github.com/djthorpe/go-errors"
@@ -25,6 +28,7 @@ import (
 
 type conn struct {
 	*driver.Client
+	sync.RWMutex
 
 	// The URL used to connect
 	url *url.URL
@@ -39,8 +43,17 @@ type conn struct {
 	// Collection metadata mapping.
 	meta map[reflect.Type]*meta
 
+	// Default read preference, read concern and write concern, or nil
+	// to use the defaults for the server
+	readpref     *readpref.ReadPref
+	readconcern  *readconcern.ReadConcern
+	writeconcern *writeconcern.WriteConcern
+
 	// Function to trace calls
 	tracefn trace.Func
+
+	// Validate field names in collection filters and sorts
+	strict bool
 }
 
 var _ Conn = (*conn)(nil)
@@ -85,6 +98,15 @@ func Open(ctx context.Context, url *url.URL, opts ...ClientOpt) (Conn, error) {
 		options.Client().SetConnectTimeout(this.timeout),
 		options.Client().SetTimeout(this.timeout),
 	}
+	if this.readpref != nil {
+		clientOpts = append(clientOpts, options.Client().SetReadPreference(this.readpref))
+	}
+	if this.readconcern != nil {
+		clientOpts = append(clientOpts, options.Client().SetReadConcern(this.readconcern))
+	}
+	if this.writeconcern != nil {
+		clientOpts = append(clientOpts, options.Client().SetWriteConcern(this.writeconcern))
+	}
 	conn, err := driver.Connect(ctx, clientOpts...)
 	if err != nil {
 		return nil, err
@@ -127,8 +149,10 @@ func (conn *conn) Close() error {
 	}
 
 	// Release resources
+	conn.Lock()
 	conn.db = nil
 	conn.meta = nil
+	conn.Unlock()
 
 	// Return any errors
 	return result
@@ -149,18 +173,24 @@ func (conn *conn) String() string {
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
-// Ping the primary server and return any errors
+// Ping the server selected by the read preference and return any errors. The
+// read preference is set from the context, or else the default read preference
+// for the client is used
 func (conn *conn) Ping(ctx context.Context) error {
 	// Return nil if already closed
 	if conn.Client == nil {
 		return ErrOutOfOrder.With("Ping")
 	}
 
+	// Ensure context is not nil
+	ctx = c(ctx)
+
 	// Trace
 	defer trace.Do(trace.WithUrl(ctx, trace.OpPing, conn.url), conn.tracefn, time.Now())
 
 	// Perform ping
-	return conn.Client.Ping(c(ctx), readpref.Primary())
+	rp, _ := ctx.Value(ctxReadPref).(*readpref.ReadPref)
+	return conn.Client.Ping(ctx, rp)
 }
 
 // Timeout returns the default timeout for any client operations
@@ -176,6 +206,8 @@ func (conn *conn) Timeout() time.Duration {
 
 // Database returns a database with a specific name
 func (conn *conn) Database(v string) Database {
+	conn.Lock()
+	defer conn.Unlock()
 	if conn.db == nil {
 		return nil
 	} else if _, exists := conn.db[v]; !exists {
@@ -225,22 +257,30 @@ func (conn *conn) Exists(ctx context.Context, v string) bool {
 
 // Do executes a function within a transaction. If the function returns
 // any error, the transaction is rolled back. Otherwise, the transaction
-// is applied to the database.
+// is applied to the database. The context passed to the function is bound
+// to the session, so any operations which use it participate in the
+// transaction.
 func (conn *conn) Do(ctx context.Context, fn func(context.Context) error) error {
+	// Check client is open
+	if conn.Client == nil {
+		return ErrOutOfOrder.With("Do")
+	}
+
 	session, err := conn.Client.StartSession(&options.SessionOptions{})
 	if err != nil {
 		return err
 	}
 	defer session.EndSession(c(ctx))
 
-	// Add a transaction counter to the context
-	ctx = trace.WithTx(c(ctx))
-
-	// Perform operations within a transaction
-	if err := session.StartTransaction(&options.TransactionOptions{}); err != nil {
+	// Perform operations within a transaction, which must read from the
+	// primary whatever the default read preference
+	if err := session.StartTransaction(options.Transaction().SetReadPreference(readpref.Primary())); err != nil {
 		return err
 	}
 
+	// Add a transaction counter to the context, and bind the session
+	ctx = driver.NewSessionContext(trace.WithTx(c(ctx)), session)
+
 	// Commit or rollback
 	var result error
 	if err := fn(ctx); err != nil {
@@ -300,24 +340,51 @@ func c(ctx context.Context) context.Context {
 
 // register a mapping from a prototype to a collection name
 func (conn *conn) registerProto(proto any, name string) *meta {
-	t := derefType(reflect.TypeOf(proto))
-	if t.Kind() != reflect.Struct {
+	meta := NewMeta(reflect.TypeOf(proto), name)
+	if meta == nil {
 		return nil
 	}
-	if meta, exists := conn.meta[t]; exists && meta.Name == name {
-		return meta
-	} else if meta := NewMeta(t, name); meta != nil {
-		conn.meta[t] = meta
-		return meta
+
+	conn.Lock()
+	defer conn.Unlock()
+	if existing, exists := conn.meta[meta.Type]; exists && existing.Name == name {
+		return existing
 	} else {
-		return nil
+		conn.meta[meta.Type] = meta
+		return meta
 	}
 }
 
-// return metadata from prototype
+// return metadata from prototype, creating the metadata with the
+// default collection name if it has not been registered
 func (conn *conn) protoToMeta(proto any) *meta {
-	t := derefType(reflect.TypeOf(proto))
-	return conn.meta[t]
+	t := reflect.TypeOf(proto)
+	if t == nil {
+		return nil
+	}
+
+	// Return registered metadata
+	t = derefType(t)
+	conn.RLock()
+	meta, exists := conn.meta[t]
+	conn.RUnlock()
+	if exists {
+		return meta
+	}
+
+	// Create metadata for the type, unless it has been registered
+	// in the meantime
+	if meta = NewMeta(t, ""); meta == nil {
+		return nil
+	}
+	conn.Lock()
+	defer conn.Unlock()
+	if existing, exists := conn.meta[t]; exists {
+		return existing
+	} else if conn.meta != nil {
+		conn.meta[t] = meta
+	}
+	return meta
 }
 
 // Return metadata from more than one prototype which
@@ -327,25 +394,16 @@ func (conn *conn) protosToMeta(protos ...any) *meta {
 	if len(protos) == 0 {
 		return nil
 	}
-	// Check for nil
-	if protos[0] == nil {
-		return nil
-	}
 
 	// Get name from collection or type
 	meta := conn.protoToMeta(protos[0])
 	if meta == nil {
-		meta = NewMeta(reflect.TypeOf(protos[0]), "")
-		if meta == nil {
-			return nil
-		} else {
-			conn.meta[meta.Type] = meta
-		}
+		return nil
 	}
 
-	// Return emptyCollection if remaining protos are different
-	if len(protos) > 1 {
-		if otherMeta := conn.protosToMeta(protos[1:]...); otherMeta == nil || otherMeta != meta {
+	// Return nil if remaining protos are different
+	for _, proto := range protos[1:] {
+		if other := conn.protoToMeta(proto); other != meta {
 			return nil
 		}
 	}
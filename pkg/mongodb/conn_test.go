@@ -2,6 +2,8 @@ package mongodb_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"testing"
@@ -118,6 +120,68 @@ func Test_Client_007(t *testing.T) {
 	assert.NotEmpty(databases)
 }
 
+func Test_Client_008(t *testing.T) {
+	assert := assert.New(t)
+
+	type Doc struct {
+		Key  string `bson:"_id,omitempty"`
+		Name string `bson:"name"`
+	}
+
+	// Operations within a transaction
+	c, err := mongodb.Open(context.TODO(), uri(t), mongodb.OptDatabase("test"))
+	assert.NoError(err)
+	defer c.Close()
+
+	// Transactions require a replica set
+	if err := c.Do(context.TODO(), func(ctx context.Context) error {
+		_, err := c.Collection(Doc{}).Find(ctx, nil, nil)
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}); err != nil {
+		t.Skip("transactions not supported, skipping test: ", err)
+	}
+
+	t.Run("001", func(t *testing.T) {
+		// Insert a document and then rollback
+		name := fmt.Sprint("rollback-", time.Now().UnixNano())
+		err := c.Do(context.TODO(), func(ctx context.Context) error {
+			assert.NoError(c.Insert(ctx, Doc{Name: name}))
+
+			// The document is visible within the transaction
+			filter := c.F()
+			assert.NoError(filter.Eq("name", name))
+			_, err := c.Collection(Doc{}).Find(ctx, nil, filter)
+			assert.NoError(err)
+
+			return ErrNotImplemented
+		})
+		assert.ErrorIs(err, ErrNotImplemented)
+
+		// The document has been discarded
+		filter := c.F()
+		assert.NoError(filter.Eq("name", name))
+		_, err = c.Collection(Doc{}).Find(context.TODO(), nil, filter)
+		assert.ErrorIs(err, ErrNotFound)
+	})
+
+	t.Run("002", func(t *testing.T) {
+		// Insert a document and then commit
+		name := fmt.Sprint("commit-", time.Now().UnixNano())
+		assert.NoError(c.Do(context.TODO(), func(ctx context.Context) error {
+			return c.Insert(ctx, Doc{Name: name})
+		}))
+
+		// The document has been applied
+		filter := c.F()
+		assert.NoError(filter.Eq("name", name))
+		_, err := c.Collection(Doc{}).Find(context.TODO(), nil, filter)
+		assert.NoError(err)
+	})
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Return URL or skip test
 
@@ -19,6 +19,7 @@ type database struct {
 
 	metaFn  metaLookupFunc // Function to return collection metadata from prototypes
 	traceFn trace.Func     // Function to trace operations
+	strict  bool           // Validate field names in collection filters and sorts
 }
 
 // Ensure *database implements the Database interface
@@ -34,6 +35,7 @@ func NewDatabase(conn *conn, name string, meta metaLookupFunc, trace trace.Func)
 		Database: conn.Client.Database(name),
 		metaFn:   meta,
 		traceFn:  trace,
+		strict:   conn.strict,
 	}
 }
 
@@ -65,7 +67,7 @@ func (database *database) Collection(proto any) Collection {
 	if meta := database.metaFn(proto); meta == nil {
 		return nil
 	} else {
-		return NewCollection(database.Database, meta, database.traceFn)
+		return NewCollection(database.Database, meta, database.traceFn, database.strict)
 	}
 }
 
@@ -76,6 +78,6 @@ func (database *database) collectionForProtos(proto ...any) *collection {
 	if meta := database.metaFn(proto...); meta == nil {
 		return nil
 	} else {
-		return NewCollection(database.Database, meta, database.traceFn)
+		return NewCollection(database.Database, meta, database.traceFn, database.strict)
 	}
 }
@@ -34,8 +34,14 @@ func (collection *collection) Delete(ctx context.Context, filter ...Filter) (int
 	ctx, matched, modified := trace.WithCollection(ctx, trace.OpDelete, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return -1, err
+	}
+
 	// Perform the delete
-	result, err := collection.Collection.DeleteOne(ctx, and(filter...), &options.DeleteOptions{})
+	result, err := coll.DeleteOne(ctx, and(filter...), &options.DeleteOptions{})
 	if err != nil {
 		return -1, err
 	} else {
@@ -60,8 +66,14 @@ func (collection *collection) DeleteMany(ctx context.Context, filter ...Filter)
 	ctx, matched, modified := trace.WithCollection(ctx, trace.OpDeleteMany, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return -1, err
+	}
+
 	// Perform the delete
-	result, err := collection.Collection.DeleteMany(ctx, and(filter...), &options.DeleteOptions{})
+	result, err := coll.DeleteMany(ctx, and(filter...), &options.DeleteOptions{})
 	if err != nil {
 		return -1, err
 	} else {
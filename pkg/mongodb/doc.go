@@ -19,5 +19,20 @@ Use the following flags:
     is not part of the underlying MongoDB driver)
   - index  - An index is generated for the field (This is not part of the underlying MongoDB driver)
   - omitempty - The field is omitted from the document if it is empty
+  - inline - The fields of an embedded struct are added to the document, rather than
+    as a nested document. An inline map can contain fields with any name.
+  - search - The field is included in the full-text search index, which is created with
+    the collection and used by the Search method (This is not part of the underlying
+    MongoDB driver)
+
+Fields without a name in the tag use the lowercased field name. The metadata for each
+type is determined once and cached, and includes the paths to fields in nested documents
+(for example, "address.city") but not fields of recursive types.
+
+# Strict Mode
+
+When a connection is opened with the OptStrict(true) option, the filters and sorts returned
+by the F() and S() methods of a collection validate field names (including dotted paths)
+against the fields of the collection documents, and return ErrBadParameter for unknown fields.
 */
 package mongodb
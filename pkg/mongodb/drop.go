@@ -0,0 +1,53 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	// Packages
+	trace "github.com/mutablelogic/go-accessory/pkg/trace"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Drop the default database
+func (conn *conn) Drop(ctx context.Context) error {
+	if db := conn.Database(defaultDatabase); db == nil {
+		return ErrOutOfOrder.With("Drop")
+	} else {
+		return db.Drop(ctx)
+	}
+}
+
+// Drop the database and all collections within it
+func (database *database) Drop(ctx context.Context) error {
+	if database == nil || database.Database == nil {
+		return ErrOutOfOrder
+	}
+
+	// Trace
+	ctx, _, _ = trace.WithCollection(c(ctx), trace.OpDropDatabase, database.Name(), emptyCollection)
+	defer trace.Do(ctx, database.traceFn, time.Now())
+
+	// Drop the database
+	return database.Database.Drop(ctx)
+}
+
+// Drop the collection and all documents within it
+func (collection *collection) Drop(ctx context.Context) error {
+	// Check for collection
+	if collection.Collection == nil {
+		return ErrOutOfOrder
+	}
+
+	// Trace
+	ctx, _, _ = trace.WithCollection(c(ctx), trace.OpDropCollection, collection.Database().Name(), collection.Name())
+	defer trace.Do(ctx, collection.traceFn, time.Now())
+
+	// Drop the collection
+	return collection.Collection.Drop(ctx)
+}
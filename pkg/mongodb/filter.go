@@ -14,6 +14,9 @@ import (
 
 type filter struct {
 	bson.M
+
+	// Metadata used to validate field names, or nil
+	meta *meta
 }
 
 var _ Filter = (*filter)(nil)
@@ -22,7 +25,13 @@ var _ Filter = (*filter)(nil)
 // LIFECYCLE
 
 func NewFilter() *filter {
-	return &filter{bson.M{}}
+	return &filter{bson.M{}, nil}
+}
+
+// NewStrictFilter returns a filter which validates field names against
+// the fields of a document
+func NewStrictFilter(meta *meta) *filter {
+	return &filter{bson.M{}, meta}
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -39,6 +48,9 @@ func (filter *filter) Key(v string) error {
 }
 
 func (filter *filter) Eq(field string, v any) error {
+	if err := filter.validate(field); err != nil {
+		return err
+	}
 	filter.M[field] = bson.M{
 		"$eq": v,
 	}
@@ -46,6 +58,9 @@ func (filter *filter) Eq(field string, v any) error {
 }
 
 func (filter *filter) Not(field string, v any) error {
+	if err := filter.validate(field); err != nil {
+		return err
+	}
 	filter.M[field] = bson.M{
 		"$ne": v,
 	}
@@ -55,6 +70,14 @@ func (filter *filter) Not(field string, v any) error {
 ///////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
+// validate a field name, if the filter is strict
+func (filter *filter) validate(field string) error {
+	if filter.meta == nil {
+		return nil
+	}
+	return filter.meta.Validate(field)
+}
+
 // and together a set of filters
 func and(f ...Filter) any {
 	var elems bson.A
@@ -0,0 +1,87 @@
+package mongodb_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	// Packages
+	mongodb "github.com/mutablelogic/go-accessory/pkg/mongodb"
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+func Test_Filter_001(t *testing.T) {
+	type Address struct {
+		City string `bson:"city"`
+	}
+	type Doc struct {
+		Key      string            `bson:"_id,omitempty"`
+		AccessAt time.Time         `bson:"access_at"`
+		Address  []Address         `bson:"address"`
+		Labels   map[string]string `bson:"labels"`
+	}
+	meta := mongodb.NewMeta(reflect.TypeOf(Doc{}), "")
+
+	t.Run("001", func(t *testing.T) {
+		assert := assert.New(t)
+		tests := []struct {
+			Field string
+			Err   error
+		}{
+			{"_id", nil},
+			{"access_at", nil},
+			{"acess_at", ErrBadParameter},
+			{"address", nil},
+			{"address.city", nil},
+			{"address.0.city", nil},
+			{"address.$.city", nil},
+			{"address.town", ErrBadParameter},
+			{"labels.any", nil},
+			{"access_at.any", ErrBadParameter},
+			{"", ErrBadParameter},
+			{"address..city", ErrBadParameter},
+		}
+		for _, test := range tests {
+			assert.ErrorIs(meta.Validate(test.Field), test.Err, test.Field)
+		}
+	})
+
+	t.Run("002", func(t *testing.T) {
+		assert := assert.New(t)
+		filter := mongodb.NewStrictFilter(meta)
+		assert.NoError(filter.Eq("access_at", time.Now()))
+		assert.ErrorIs(filter.Eq("acess_at", time.Now()), ErrBadParameter)
+		assert.NoError(filter.Eq("address.city", "Berlin"))
+		assert.ErrorIs(filter.Not("address.town", "Berlin"), ErrBadParameter)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		assert := assert.New(t)
+		sort := mongodb.NewStrictSort(meta)
+		assert.NoError(sort.Desc("access_at"))
+		assert.ErrorIs(sort.Desc("acces_at"), ErrBadParameter)
+		assert.ErrorIs(sort.Asc("_id", "acces_at"), ErrBadParameter)
+	})
+
+	t.Run("004", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.NoError(mongodb.NewFilter().Eq("acess_at", time.Now()))
+		assert.NoError(mongodb.NewSort().Desc("acces_at"))
+	})
+
+	t.Run("005", func(t *testing.T) {
+		assert := assert.New(t)
+		type NoKey struct {
+			Name string `bson:"name"`
+		}
+		meta := mongodb.NewMeta(reflect.TypeOf(NoKey{}), "")
+		assert.NoError(meta.Validate("_id"))
+		assert.ErrorIs(meta.Validate("_id.any"), ErrBadParameter)
+		assert.NoError(mongodb.NewStrictFilter(meta).Eq("_id", "x"))
+		assert.NoError(mongodb.NewStrictSort(meta).Asc("_id", "name"))
+		assert.ErrorIs(mongodb.NewStrictSort(meta).Asc("_id", "key"), ErrBadParameter)
+	})
+}
@@ -31,9 +31,16 @@ func (collection *collection) Find(ctx context.Context, sort Sort, filter ...Fil
 	ctx, matched, _ := trace.WithCollection(ctx, trace.OpFind, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Do the find
-	result := collection.Collection.FindOne(ctx, and(filter...), &options.FindOneOptions{
-		Sort: sortdoc(sort),
+	result := coll.FindOne(ctx, and(filter...), &options.FindOneOptions{
+		Sort:       sortdoc(sort),
+		Projection: collection.meta.Projection(),
 	})
 
 	// Check for errors
@@ -68,10 +75,17 @@ func (collection *collection) FindMany(ctx context.Context, sort Sort, filter ..
 	ctx, _, _ = trace.WithCollection(ctx, trace.OpFindMany, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Do the find
-	cur, err := collection.Collection.Find(ctx, and(filter...), &options.FindOptions{
-		Sort:  sortdoc(sort),
-		Limit: sortlimit(sort),
+	cur, err := coll.Find(ctx, and(filter...), &options.FindOptions{
+		Sort:       sortdoc(sort),
+		Limit:      sortlimit(sort),
+		Projection: collection.meta.Projection(),
 	})
 
 	// Check for errors
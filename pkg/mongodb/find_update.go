@@ -32,9 +32,16 @@ func (collection *collection) FindUpdate(ctx context.Context, patch any, sort So
 	ctx, matched, _ := trace.WithCollection(ctx, trace.OpFindUpdate, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Execute operation
-	result := collection.Collection.FindOneAndUpdate(ctx, and(filter...), bson.D{{"$set", patch}}, &options.FindOneAndUpdateOptions{
-		Sort: sortdoc(sort),
+	result := coll.FindOneAndUpdate(ctx, and(filter...), bson.D{{"$set", patch}}, &options.FindOneAndUpdateOptions{
+		Sort:       sortdoc(sort),
+		Projection: collection.meta.Projection(),
 	})
 
 	// Check for errors
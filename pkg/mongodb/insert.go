@@ -10,6 +10,7 @@ import (
 	. "github.com/djthorpe/go-errors"
 	"github.com/hashicorp/go-multierror"
 	"github.com/mutablelogic/go-accessory/pkg/trace"
+	driver "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -46,16 +47,21 @@ func (collection *collection) Insert(ctx context.Context, doc ...any) error {
 	ctx, _, modified := trace.WithCollection(ctx, trace.OpInsert, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Call one or many
-	var err error
 	switch len(doc) {
 	case 0:
 		return ErrBadParameter
 	case 1:
-		*modified, err = collection.insertOne(ctx, doc[0])
+		*modified, err = collection.insertOne(ctx, coll, doc[0])
 		return err
 	default:
-		*modified, err = collection.insertMany(ctx, doc...)
+		*modified, err = collection.insertMany(ctx, coll, doc...)
 		return err
 	}
 }
@@ -63,8 +69,8 @@ func (collection *collection) Insert(ctx context.Context, doc ...any) error {
 ///////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
-func (collection *collection) insertOne(ctx context.Context, doc any) (int64, error) {
-	r, err := collection.Collection.InsertOne(ctx, doc, &options.InsertOneOptions{})
+func (collection *collection) insertOne(ctx context.Context, coll *driver.Collection, doc any) (int64, error) {
+	r, err := coll.InsertOne(ctx, doc, &options.InsertOneOptions{})
 	if err != nil {
 		return -1, err
 	}
@@ -75,9 +81,9 @@ func (collection *collection) insertOne(ctx context.Context, doc any) (int64, er
 	}
 }
 
-func (collection *collection) insertMany(ctx context.Context, doc ...any) (int64, error) {
+func (collection *collection) insertMany(ctx context.Context, coll *driver.Collection, doc ...any) (int64, error) {
 	var result error
-	r, err := collection.Collection.InsertMany(ctx, doc, &options.InsertManyOptions{})
+	r, err := coll.InsertMany(ctx, doc, &options.InsertManyOptions{})
 	if err != nil {
 		return int64(len(r.InsertedIDs)), err
 	}
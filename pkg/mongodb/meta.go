@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	// Packages
+	bson "go.mongodb.org/mongo-driver/bson"
+	bsoncodec "go.mongodb.org/mongo-driver/bson/bsoncodec"
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 
 	// Namespace imports
@@ -25,6 +29,52 @@ type meta struct {
 
 	// Field index which is used as the primary key
 	Key []int
+
+	// Fields in the document, including fields in nested documents
+	Fields []*field
+
+	// True if the document has an inline map, which can contain
+	// fields with any name
+	InlineMap bool
+
+	// Mapping from dotted path to field
+	paths map[string]*field
+
+	// Projection for the fields in the document
+	projection bson.D
+}
+
+// field is the metadata for a field in a document
+type field struct {
+	// Name of the field in the document
+	Name string
+
+	// Dotted path of the field from the root of the document
+	Path string
+
+	// Field index sequence from the root of the document. Fields in
+	// nested documents within arrays are not addressable by index.
+	Index []int
+
+	// Go type of the field
+	Type reflect.Type
+
+	// The field is omitted from the document if it is empty
+	OmitEmpty bool
+
+	// The field is included in the full-text search index
+	Search bool
+
+	// The field is a nested document, and its fields are recorded
+	// with the path as prefix
+	Document bool
+
+	// Depth of inline structs in which the field is declared
+	depth int
+
+	// The field can contain fields which are not recorded, for example
+	// a map or a recursive document
+	open bool
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -36,40 +86,146 @@ const (
 
 	// Field name which is used as the primary key
 	structKey = "_id"
+
+	// Tag flags
+	structInline    = "inline"
+	structOmitEmpty = "omitempty"
+	structSearch    = "search"
+
+	// Separator for paths to fields in nested documents
+	pathSeparator = "."
+)
+
+var (
+	// Process-wide cache of document metadata, keyed by type, without
+	// any collection name
+	metaCache sync.Map
+
+	// Types which are encoded as values rather than nested documents
+	typeTime       = reflect.TypeOf(time.Time{})
+	typeMarshaler  = reflect.TypeOf((*bson.Marshaler)(nil)).Elem()
+	typeVMarshaler = reflect.TypeOf((*bsoncodec.ValueMarshaler)(nil)).Elem()
+	pkgPrimitive   = reflect.TypeOf(primitive.ObjectID{}).PkgPath()
 )
 
 ///////////////////////////////////////////////////////////////////////////////
 // LIFECYCLE
 
+// NewMeta returns the metadata for a struct type with the given collection
+// name, or nil if the type is not a struct. The metadata for each type
+// is cached, so the fields are only determined once
 func NewMeta(t reflect.Type, name string) *meta {
-	meta := new(meta)
-	meta.Name = name
+	if t == nil {
+		return nil
+	}
 	t = derefType(t)
 	if t.Kind() != reflect.Struct {
 		return nil
-	} else {
-		meta.Type = t
 	}
 
-	// Fix name
-	if meta.Name == "" {
+	// Obtain cached metadata, or create new metadata for the type
+	cached, exists := metaCache.Load(t)
+	if !exists {
+		cached, _ = metaCache.LoadOrStore(t, newTypeMeta(t))
+	}
+
+	// Make a copy of the cached metadata and set the name
+	meta := *cached.(*meta)
+	if meta.Name = name; meta.Name == "" {
 		meta.Name = t.Name()
 	}
 
-	// Find the field which is used as the primary key
-	fields := reflect.VisibleFields(t)
-	for _, field := range fields {
-		name, _ := structTagValue(field)
-		if name == "" {
-			continue
-		} else if name == structKey {
-			meta.Key = field.Index
+	// Return success
+	return &meta
+}
+
+// Create metadata for a struct type
+func newTypeMeta(t reflect.Type) *meta {
+	meta := new(meta)
+	meta.Type = t
+	meta.paths = make(map[string]*field)
+
+	// Walk the fields of the struct
+	meta.walk(t, nil, nil, 0, true, map[reflect.Type]bool{t: true})
+
+	// Set the field which is used as the primary key, if it is addressable
+	if key, exists := meta.paths[structKey]; exists && key.Index != nil {
+		meta.Key = key.Index
+	}
+
+	// Set the projection from the top-level fields
+	for _, field := range meta.Fields {
+		if !strings.Contains(field.Path, pathSeparator) {
+			meta.projection = append(meta.projection, bson.E{Key: field.Path, Value: 1})
 		}
 	}
 
+	// Return the metadata
 	return meta
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Field returns the field with a dotted path, or nil if the field
+// is not in the document
+func (meta *meta) Field(path string) *field {
+	return meta.paths[path]
+}
+
+// Projection returns the projection document for the fields of the
+// document, or nil if all fields should be returned
+func (meta *meta) Projection() any {
+	if meta.InlineMap || len(meta.projection) == 0 {
+		return nil
+	}
+	return meta.projection
+}
+
+// SearchFields returns the dotted paths of fields which are included in
+// the full-text search index, or nil if there are none
+func (meta *meta) SearchFields() []string {
+	var result []string
+	for _, field := range meta.Fields {
+		if field.Search {
+			result = append(result, field.Path)
+		}
+	}
+	return result
+}
+
+// Validate returns ErrBadParameter if a dotted path does not refer to a field
+// in the document. Array indexes and positional operators in the path are
+// ignored, and paths within maps, interfaces and recursive documents are not
+// validated beyond the field which contains them. The "_id" field is always
+// valid, as every document has one whether or not it is in the struct.
+func (meta *meta) Validate(path string) error {
+	if path == structKey {
+		return nil
+	}
+	var prefix string
+	for i, elem := range strings.Split(path, pathSeparator) {
+		if elem == "" {
+			return ErrBadParameter.Withf("invalid field %q for collection %q", path, meta.Name)
+		} else if i > 0 && isArrayElem(elem) {
+			continue
+		}
+		if prefix += elem; i == 0 && meta.InlineMap && meta.paths[prefix] == nil {
+			return nil
+		}
+		field := meta.paths[prefix]
+		if field == nil {
+			return ErrBadParameter.Withf("unknown field %q for collection %q", path, meta.Name)
+		} else if field.open {
+			return nil
+		}
+		prefix += pathSeparator
+	}
+
+	// Return success
+	return nil
+}
+
 // Set the key for a document. Return ErrNotModified if the key
 // cannot be set in the document.
 func (meta *meta) SetKey(doc, key any) (string, error) {
@@ -80,9 +236,9 @@ func (meta *meta) SetKey(doc, key any) (string, error) {
 	if meta.Key == nil || !v.CanSet() {
 		return "", ErrNotModified.Withf("SetKey: cannot set key in document of type %T", doc)
 	}
-	// Obtain the field to set
-	f := v.FieldByIndex(meta.Key)
-	if !f.CanSet() {
+	// Obtain the field to set, which fails on a nil embedded struct pointer
+	f, err := v.FieldByIndexErr(meta.Key)
+	if err != nil || !f.CanSet() {
 		return "", ErrNotModified.Withf("SetKey: cannot set key in document of type %T", doc)
 	}
 	// If the field type matches the key type, then set directly
@@ -114,6 +270,146 @@ func keyToString(key any) string {
 ///////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
+// walk the fields of a struct type, adding them to the metadata with the
+// given index and parent field. Inline fields are added with the same parent,
+// and fields of nested documents are added with the field as parent.
+// The index is nil when the fields are not addressable from the root
+// of the document. Types which are already being walked are not walked
+// again, so fields of recursive types are not added.
+func (meta *meta) walk(t reflect.Type, index []int, parent *field, depth int, addressable bool, visited map[reflect.Type]bool) {
+	prefix := ""
+	if parent != nil {
+		prefix = parent.Path + pathSeparator
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		// Ignore unexported fields
+		if f.PkgPath != "" {
+			continue
+		}
+
+		// Ignore fields with "-" tag
+		name, flags := structTagValue(f)
+		if name == "" {
+			continue
+		}
+
+		// Set the index of the field
+		var fieldIndex []int
+		if addressable {
+			fieldIndex = append(append([]int{}, index...), i)
+		}
+
+		// Inline fields are either a map, which can contain any fields, or
+		// a struct, whose fields are added with the same parent
+		if _, inline := flags[structInline]; inline {
+			ft := f.Type
+			if ft.Kind() == reflect.Map {
+				if parent == nil {
+					meta.InlineMap = true
+				} else {
+					parent.open = true
+				}
+				continue
+			}
+			if ft.Kind() == reflect.Ptr {
+				ft, fieldIndex = ft.Elem(), nil
+			}
+			if ft.Kind() == reflect.Struct && !visited[ft] {
+				visited[ft] = true
+				meta.walk(ft, fieldIndex, parent, depth+1, fieldIndex != nil, visited)
+				delete(visited, ft)
+			}
+			continue
+		}
+
+		// Add the field
+		_, omitempty := flags[structOmitEmpty]
+		_, search := flags[structSearch]
+		field := &field{
+			Name:      name,
+			Path:      prefix + name,
+			Index:     fieldIndex,
+			Type:      f.Type,
+			OmitEmpty: omitempty,
+			Search:    search,
+			depth:     depth,
+		}
+		if !meta.add(field) {
+			continue
+		}
+
+		// Add fields for nested documents, which may be within arrays or pointers
+		ft, nestedIndex := f.Type, fieldIndex
+		for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
+			ft, nestedIndex = ft.Elem(), nil
+		}
+		switch {
+		case ft.Kind() == reflect.Map || ft.Kind() == reflect.Interface:
+			field.open = true
+		case isDocument(ft) && visited[ft]:
+			field.Document, field.open = true, true
+		case isDocument(ft):
+			field.Document = true
+			visited[ft] = true
+			meta.walk(ft, nestedIndex, field, 0, nestedIndex != nil, visited)
+			delete(visited, ft)
+		}
+	}
+}
+
+// add a field to the metadata, where fields with the same path are
+// resolved by the shallowest inline depth. Returns false if the field
+// was not added
+func (meta *meta) add(field *field) bool {
+	other, exists := meta.paths[field.Path]
+	if !exists {
+		meta.Fields = append(meta.Fields, field)
+	} else if other.depth <= field.depth {
+		return false
+	} else {
+		for i := range meta.Fields {
+			if meta.Fields[i] == other {
+				meta.Fields[i] = field
+			}
+		}
+	}
+	meta.paths[field.Path] = field
+	return true
+}
+
+// isArrayElem returns true if a path element is an array index or
+// positional operator
+func isArrayElem(elem string) bool {
+	if strings.HasPrefix(elem, "$") {
+		return true
+	}
+	for _, r := range elem {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDocument returns true if the type is encoded as a nested document
+func isDocument(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if t == typeTime || t.PkgPath() == pkgPrimitive {
+		return false
+	}
+	if t.Implements(typeMarshaler) || t.Implements(typeVMarshaler) {
+		return false
+	}
+	if pt := reflect.PointerTo(t); pt.Implements(typeMarshaler) || pt.Implements(typeVMarshaler) {
+		return false
+	}
+	return true
+}
+
 // structTag returns the name for a field and options, or an empty string if
 // the field should be ignored.
 func structTagValue(f reflect.StructField) (string, map[string]string) {
@@ -123,7 +419,7 @@ func structTagValue(f reflect.StructField) (string, map[string]string) {
 		return "", nil
 	}
 
-	name := f.Name
+	name := strings.ToLower(f.Name)
 	flags := make(map[string]string)
 	for i, tag := range strings.Split(value, ",") {
 		tag = strings.TrimSpace(tag)
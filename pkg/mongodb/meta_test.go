@@ -2,11 +2,14 @@ package mongodb_test
 
 import (
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	// Packages
 	"github.com/mutablelogic/go-accessory/pkg/mongodb"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func Test_Meta_001(t *testing.T) {
@@ -39,3 +42,215 @@ func Test_Meta_001(t *testing.T) {
 		assert.Equal([]int{0}, collection.Key)
 	})
 }
+
+func Test_Meta_002(t *testing.T) {
+	type Address struct {
+		Street string `bson:"street"`
+		City   string `bson:"city,omitempty"`
+	}
+	type Common struct {
+		Key       string    `bson:"_id,omitempty"`
+		CreatedAt time.Time `bson:"created_at"`
+	}
+	type Doc struct {
+		Common  `bson:",inline"`
+		Name    string    `bson:"name"`
+		Skip    string    `bson:"-"`
+		Address Address   `bson:"address"`
+		History []Address `bson:"history,omitempty"`
+		Other   int
+		private int
+	}
+
+	t.Run("001", func(t *testing.T) {
+		assert := assert.New(t)
+		meta := mongodb.NewMeta(reflect.TypeOf(Doc{}), "")
+		assert.NotNil(meta)
+		assert.Equal("Doc", meta.Name)
+
+		// Key is in the inline struct
+		assert.Equal([]int{0, 0}, meta.Key)
+	})
+
+	t.Run("002", func(t *testing.T) {
+		assert := assert.New(t)
+		meta := mongodb.NewMeta(reflect.TypeOf(Doc{}), "")
+		for _, path := range []string{"_id", "created_at", "name", "address", "address.street", "address.city", "history", "history.street", "other"} {
+			field := meta.Field(path)
+			if assert.NotNil(field, path) {
+				assert.Equal(path, field.Path)
+			}
+		}
+		for _, path := range []string{"Common", "Skip", "skip", "private", "address.zip", "created_at.wall"} {
+			assert.Nil(meta.Field(path), path)
+		}
+	})
+
+	t.Run("003", func(t *testing.T) {
+		assert := assert.New(t)
+		meta := mongodb.NewMeta(reflect.TypeOf(Doc{}), "")
+		assert.True(meta.Field("_id").OmitEmpty)
+		assert.True(meta.Field("address.city").OmitEmpty)
+		assert.False(meta.Field("address.street").OmitEmpty)
+		assert.True(meta.Field("address").Document)
+		assert.True(meta.Field("history").Document)
+		assert.False(meta.Field("created_at").Document)
+
+		// Fields within arrays are not addressable by index
+		assert.Equal([]int{3, 0}, meta.Field("address.street").Index)
+		assert.Nil(meta.Field("history.street").Index)
+	})
+
+	t.Run("004", func(t *testing.T) {
+		assert := assert.New(t)
+		a := mongodb.NewMeta(reflect.TypeOf(Doc{}), "a")
+		b := mongodb.NewMeta(reflect.TypeOf(&Doc{}), "b")
+		assert.Equal("a", a.Name)
+		assert.Equal("b", b.Name)
+		assert.Equal(a.Fields, b.Fields)
+		assert.Equal(bson.D{{Key: "_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "name", Value: 1}, {Key: "address", Value: 1}, {Key: "history", Value: 1}, {Key: "other", Value: 1}}, a.Projection())
+	})
+}
+
+func Test_Meta_003(t *testing.T) {
+	type Node struct {
+		Name     string            `bson:"name"`
+		Children []Node            `bson:"children"`
+		Extra    map[string]string `bson:",inline"`
+	}
+
+	t.Run("001", func(t *testing.T) {
+		assert := assert.New(t)
+		meta := mongodb.NewMeta(reflect.TypeOf(Node{}), "")
+		assert.NotNil(meta)
+		assert.True(meta.InlineMap)
+		assert.Nil(meta.Projection())
+		assert.NotNil(meta.Field("children"))
+		assert.Nil(meta.Field("children.name"))
+	})
+
+	t.Run("002", func(t *testing.T) {
+		assert := assert.New(t)
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NotNil(mongodb.NewMeta(reflect.TypeOf(Node{}), ""))
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func Test_Meta_004(t *testing.T) {
+	type Author struct {
+		Name string `bson:"name,search"`
+	}
+	type Doc struct {
+		Key    string `bson:"_id,omitempty"`
+		Title  string `bson:"title,search"`
+		Body   string `bson:"body,omitempty,search"`
+		Author Author `bson:"author"`
+		Tags   []string
+	}
+
+	assert := assert.New(t)
+	meta := mongodb.NewMeta(reflect.TypeOf(Doc{}), "")
+	assert.NotNil(meta)
+	assert.Equal([]string{"title", "body", "author.name"}, meta.SearchFields())
+	assert.True(meta.Field("body").OmitEmpty)
+	assert.False(meta.Field("tags").Search)
+}
+
+func Test_Meta_005(t *testing.T) {
+	type Common struct {
+		Key string `bson:"_id,omitempty"`
+	}
+	type Doc struct {
+		Common
+		Name string `bson:"name"`
+	}
+	type InlineDoc struct {
+		Common `bson:",inline"`
+		Name   string `bson:"name"`
+	}
+	type InlinePtrDoc struct {
+		*Common `bson:",inline"`
+		Name    string `bson:"name"`
+	}
+
+	assert := assert.New(t)
+
+	t.Run("Embedded", func(t *testing.T) {
+		// Embedded structs are nested documents, so there is no key
+		meta := mongodb.NewMeta(reflect.TypeOf(Doc{}), "")
+		assert.NotNil(meta)
+		assert.Nil(meta.Key)
+		assert.NotNil(meta.Field("common._id"))
+		assert.Nil(meta.Field("_id"))
+	})
+
+	t.Run("Inline", func(t *testing.T) {
+		// Key is promoted from the inline struct
+		meta := mongodb.NewMeta(reflect.TypeOf(InlineDoc{}), "")
+		assert.NotNil(meta)
+		assert.Equal([]int{0, 0}, meta.Key)
+
+		doc := InlineDoc{}
+		key, err := meta.SetKey(&doc, "x")
+		assert.NoError(err)
+		assert.Equal("x", key)
+		assert.Equal("x", doc.Key)
+	})
+
+	t.Run("InlinePtr", func(t *testing.T) {
+		// Key within an inline struct pointer cannot be set
+		meta := mongodb.NewMeta(reflect.TypeOf(InlinePtrDoc{}), "")
+		assert.NotNil(meta)
+		assert.Nil(meta.Key)
+		assert.NotNil(meta.Field("_id"))
+
+		_, err := meta.SetKey(&InlinePtrDoc{}, "x")
+		assert.Error(err)
+	})
+}
+
+func Test_Meta_006(t *testing.T) {
+	type Address struct {
+		Street string `bson:"street"`
+	}
+	type Common struct {
+		Key string `bson:"_id,omitempty"`
+	}
+	type Extra struct {
+		Note string `bson:"note"`
+	}
+	type Doc struct {
+		Common  `bson:",inline"`
+		*Extra  `bson:",inline"`
+		Name    string  `bson:"name"`
+		Address Address `bson:"address"`
+	}
+	type Open struct {
+		Name  string         `bson:"name"`
+		Other map[string]any `bson:",inline"`
+	}
+
+	t.Run("Inline", func(t *testing.T) {
+		// Fields of inline structs are projected at the top level, and
+		// nested documents are projected as a whole
+		assert := assert.New(t)
+		meta := mongodb.NewMeta(reflect.TypeOf(Doc{}), "")
+		assert.NotNil(meta)
+		assert.Equal(bson.D{{Key: "_id", Value: 1}, {Key: "note", Value: 1}, {Key: "name", Value: 1}, {Key: "address", Value: 1}}, meta.Projection())
+	})
+
+	t.Run("InlineMap", func(t *testing.T) {
+		// All fields are returned when there is an inline map
+		assert := assert.New(t)
+		meta := mongodb.NewMeta(reflect.TypeOf(Open{}), "")
+		assert.NotNil(meta)
+		assert.Nil(meta.Projection())
+	})
+}
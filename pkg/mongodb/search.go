@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	// Packages
+	trace "github.com/mutablelogic/go-accessory/pkg/trace"
+	bson "go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	options "go.mongodb.org/mongo-driver/mongo/options"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-accessory"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Name of the full-text search index
+	searchIndex = "search"
+
+	// Field which contains the relevance of a document
+	searchScore = "_score"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Search returns an iterable cursor of documents which match a full-text
+// search query and filter parameters, ordered by relevance. The collection
+// requires a text index, which is created by CreateCollection when fields
+// have the "search" tag flag.
+func (collection *collection) Search(ctx context.Context, query string, filter ...Filter) (Cursor, error) {
+	// Check for collection
+	if collection.Collection == nil {
+		return nil, ErrOutOfOrder
+	} else if query == "" {
+		return nil, ErrBadParameter.With("Search: empty query")
+	}
+
+	// Trace
+	ctx, _, _ = trace.WithCollection(ctx, trace.OpSearch, collection.Database().Name(), collection.Name())
+	defer trace.Do(ctx, collection.traceFn, time.Now())
+
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the text search to the filters
+	search := NewFilter()
+	search.M["$text"] = bson.M{"$search": query}
+	filter = append([]Filter{search}, filter...)
+
+	// Do the search, ordered by relevance
+	score := bson.D{{Key: "$meta", Value: "textScore"}}
+	cur, err := coll.Find(ctx, and(filter...), &options.FindOptions{
+		Sort:       bson.D{{Key: searchScore, Value: score}},
+		Projection: searchProjection(collection.meta.Projection(), score),
+	})
+
+	// Check for errors
+	if errors.Is(err, driver.ErrNoDocuments) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Return the cursor
+	return NewCursor(cur, collection.meta.Type), nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Create a text index for the search fields of a collection, if there are any
+func createSearchIndex(ctx context.Context, coll *driver.Collection, meta *meta) error {
+	fields := meta.SearchFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	keys := make(bson.D, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, bson.E{Key: field, Value: "text"})
+	}
+	_, err := coll.Indexes().CreateOne(ctx, driver.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName(searchIndex),
+	})
+	return err
+}
+
+// Return a projection which includes the text score, which is required
+// for sorting by relevance
+func searchProjection(projection any, score bson.D) any {
+	if projection, ok := projection.(bson.D); ok {
+		return append(append(bson.D{}, projection...), bson.E{Key: searchScore, Value: score})
+	}
+	return bson.D{{Key: searchScore, Value: score}}
+}
@@ -0,0 +1,81 @@
+package mongodb_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	// Packages
+	mongodb "github.com/mutablelogic/go-accessory/pkg/mongodb"
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+func Test_Search_001(t *testing.T) {
+	assert := assert.New(t)
+
+	type Article struct {
+		Key   string `bson:"_id,omitempty"`
+		Title string `bson:"title,search"`
+		Body  string `bson:"body,search"`
+		Draft bool   `bson:"draft"`
+	}
+
+	c, err := mongodb.Open(context.TODO(), uri(t))
+	assert.NoError(err)
+	defer c.Close()
+
+	// Use a new database for each test run
+	db := c.Database(t.Name() + "_" + time.Now().Format("20060102150405"))
+	assert.NotNil(db)
+	defer db.Drop(context.TODO())
+
+	// Create the collection with a search index
+	collection, err := db.CreateCollection(context.TODO(), Article{})
+	assert.NoError(err)
+	assert.NoError(db.Insert(context.TODO(),
+		Article{Title: "Full-text search", Body: "Searching documents by relevance"},
+		Article{Title: "Indexes", Body: "Documents are indexed for search", Draft: true},
+		Article{Title: "Transactions", Body: "Sessions and commits"},
+	))
+
+	t.Run("001", func(t *testing.T) {
+		cursor, err := collection.Search(context.TODO(), "search")
+		assert.NoError(err)
+		defer cursor.Close()
+
+		var titles []string
+		for {
+			doc, err := cursor.Next(context.TODO())
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(err)
+			titles = append(titles, doc.(*Article).Title)
+		}
+		assert.Len(titles, 2)
+		assert.Equal("Full-text search", titles[0])
+	})
+
+	t.Run("002", func(t *testing.T) {
+		filter := collection.F()
+		assert.NoError(filter.Eq("draft", true))
+		cursor, err := collection.Search(context.TODO(), "search", filter)
+		assert.NoError(err)
+		defer cursor.Close()
+
+		doc, err := cursor.Next(context.TODO())
+		assert.NoError(err)
+		assert.Equal("Indexes", doc.(*Article).Title)
+		_, err = cursor.Next(context.TODO())
+		assert.Equal(io.EOF, err)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		_, err := collection.Search(context.TODO(), "")
+		assert.ErrorIs(err, ErrBadParameter)
+	})
+}
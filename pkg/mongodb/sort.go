@@ -15,6 +15,9 @@ import (
 type sort struct {
 	bson.D
 	limit *int64
+
+	// Metadata used to validate field names, or nil
+	meta *meta
 }
 
 var _ Sort = (*sort)(nil)
@@ -23,7 +26,13 @@ var _ Sort = (*sort)(nil)
 // LIFECYCLE
 
 func NewSort() *sort {
-	return &sort{bson.D{}, nil}
+	return &sort{bson.D{}, nil, nil}
+}
+
+// NewStrictSort returns a sort specification which validates field names
+// against the fields of a document
+func NewStrictSort(meta *meta) *sort {
+	return &sort{bson.D{}, nil, meta}
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -31,6 +40,9 @@ func NewSort() *sort {
 
 // Add ascending sort order
 func (sort *sort) Asc(fields ...string) error {
+	if err := sort.validate(fields...); err != nil {
+		return err
+	}
 	for _, field := range fields {
 		sort.D = append(sort.D, bson.E{field, 1})
 	}
@@ -39,6 +51,9 @@ func (sort *sort) Asc(fields ...string) error {
 
 // Add descending sort order
 func (sort *sort) Desc(fields ...string) error {
+	if err := sort.validate(fields...); err != nil {
+		return err
+	}
 	for _, field := range fields {
 		sort.D = append(sort.D, bson.E{field, -1})
 	}
@@ -57,6 +72,19 @@ func (sort *sort) Limit(limit int64) error {
 ///////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
+// validate field names, if the sort specification is strict
+func (sort *sort) validate(fields ...string) error {
+	if sort.meta == nil {
+		return nil
+	}
+	for _, field := range fields {
+		if err := sort.meta.Validate(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func sortdoc(s Sort) any {
 	if s == nil {
 		return bson.D{}
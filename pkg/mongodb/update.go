@@ -34,8 +34,14 @@ func (collection *collection) Update(ctx context.Context, patch any, filter ...F
 	ctx, matched, modified := trace.WithCollection(ctx, trace.OpUpdate, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
-	// Do the find
-	result, err := collection.Collection.UpdateOne(ctx, and(filter...), bson.D{{"$set", patch}}, &options.UpdateOptions{})
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	// Do the update
+	result, err := coll.UpdateOne(ctx, and(filter...), bson.D{{"$set", patch}}, &options.UpdateOptions{})
 	if err != nil {
 		return -1, -1, err
 	} else {
@@ -62,8 +68,14 @@ func (collection *collection) UpdateMany(ctx context.Context, patch any, filter
 	ctx, matched, modified := trace.WithCollection(ctx, trace.OpUpdateMany, collection.Database().Name(), collection.Name())
 	defer trace.Do(ctx, collection.traceFn, time.Now())
 
-	// Do the find
-	result, err := collection.Collection.UpdateMany(ctx, and(filter...), bson.D{{"$set", patch}}, &options.UpdateOptions{})
+	// Apply any overrides from the context
+	coll, err := collection.withContext(ctx)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	// Do the update
+	result, err := coll.UpdateMany(ctx, and(filter...), bson.D{{"$set", patch}}, &options.UpdateOptions{})
 	if err != nil {
 		return -1, -1, err
 	} else {
@@ -62,6 +62,46 @@ func OptCollection(collection any, name string) Option {
 	}
 }
 
+// Set the default read preference and maximum staleness
+func OptReadPreference(mode string, maxStaleness time.Duration) Option {
+	return func(pool *pool) error {
+		if pool.uri != nil && (pool.uri.Scheme == schemeMongo1 || pool.uri.Scheme == schemeMongo2) {
+			pool.mongodb = append(pool.mongodb, mongodb.OptReadPreference(mode, maxStaleness))
+		}
+		return nil
+	}
+}
+
+// Set the default read concern
+func OptReadConcern(level string) Option {
+	return func(pool *pool) error {
+		if pool.uri != nil && (pool.uri.Scheme == schemeMongo1 || pool.uri.Scheme == schemeMongo2) {
+			pool.mongodb = append(pool.mongodb, mongodb.OptReadConcern(level))
+		}
+		return nil
+	}
+}
+
+// Set the default write concern
+func OptWriteConcern(w string, journal bool, timeout time.Duration) Option {
+	return func(pool *pool) error {
+		if pool.uri != nil && (pool.uri.Scheme == schemeMongo1 || pool.uri.Scheme == schemeMongo2) {
+			pool.mongodb = append(pool.mongodb, mongodb.OptWriteConcern(w, journal, timeout))
+		}
+		return nil
+	}
+}
+
+// Set strict mode, where collection filters and sorts validate field names
+func OptStrict(v bool) Option {
+	return func(pool *pool) error {
+		if pool.uri != nil && (pool.uri.Scheme == schemeMongo1 || pool.uri.Scheme == schemeMongo2) {
+			pool.mongodb = append(pool.mongodb, mongodb.OptStrict(v))
+		}
+		return nil
+	}
+}
+
 // Set the trace function
 func OptTrace(fn trace.Func) Option {
 	return func(pool *pool) error {
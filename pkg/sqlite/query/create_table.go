@@ -1,12 +1,12 @@
 package query
 
 import (
+	"strings"
+
 	// Namespace imports
 	. "github.com/mutablelogic/go-accessory"
 )
 
-//. "github.com/mutablelogic/go-accessory/pkg/sqlite/quote"
-
 ///////////////////////////////////////////////////////////////////////////////
 // TYPES
 
@@ -35,5 +35,12 @@ func (n *name) CreateTable(c ...Name) CreateTable {
 func (table *createTable) Query() string {
 	var str string
 	str += "CREATE TABLE " + table.name.SchemaName()
+	if len(table.col) > 0 {
+		cols := make([]string, 0, len(table.col))
+		for _, col := range table.col {
+			cols = append(cols, col.Query())
+		}
+		str += " (" + strings.Join(cols, ", ") + ")"
+	}
 	return str
 }
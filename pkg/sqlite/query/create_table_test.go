@@ -19,6 +19,8 @@ func Test_CreateTable_000(t *testing.T) {
 	}{
 		{N("a").CreateTable(), `CREATE TABLE a`},
 		{N("a").WithSchema("b").CreateTable(), `CREATE TABLE b.a`},
+		{N("a").CreateTable(N("x"), N("y").WithType("TEXT")), `CREATE TABLE a (x, y TEXT)`},
+		{N("a").CreateTable(N("x").WithType("TEXT").WithCollation("natsort")), `CREATE TABLE a (x TEXT COLLATE natsort)`},
 	}
 	for _, test := range tests {
 		assert.Equal(test.String, test.In.Query())
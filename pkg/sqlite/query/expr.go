@@ -0,0 +1,75 @@
+package query
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-accessory"
+	. "github.com/mutablelogic/go-accessory/pkg/sqlite/quote"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// E returns an expression from a value. Strings and times are quoted,
+// byte slices are blob literals, nil is NULL, select statements are enclosed
+// in parentheses and any other query (for example, a name) is used as-is.
+func E(v any) Query {
+	return Q(expr(v))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// expr returns an expression from a value
+func expr(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case Select:
+		return "(" + v.Query() + ")"
+	case Query:
+		return v.Query()
+	case string:
+		return Quote(v)
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []byte:
+		return "X" + Quote(strings.ToUpper(hex.EncodeToString(v)))
+	case time.Time:
+		return Quote(v.Format(time.RFC3339))
+	default:
+		return Quote(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,217 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-accessory"
+	. "github.com/mutablelogic/go-accessory/pkg/sqlite/quote"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type fts5 struct {
+	name
+	col       []*name
+	content   *name
+	rowid     string
+	tokenizer string
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Declared type for columns which are stored but not indexed
+	ftsUnindexed = "UNINDEXED"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func (n *name) FTS5(c ...Name) FTS5 {
+	cols := make([]*name, 0, len(c))
+	for _, v := range c {
+		if v, ok := v.(*name); ok {
+			cols = append(cols, v)
+		}
+	}
+	return &fts5{name: name{query: n.query, schema: n.schema}, col: cols}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Append flags to the table creation
+func (table *fts5) With(f QueryFlag) Query {
+	result := *table
+	result.f |= f
+	return &result
+}
+
+// Use an external content table, with the column which is used as the rowid.
+// When the rowid column is empty, the rowid of the content table is used
+func (table *fts5) WithContent(content Name, rowid string) FTS5 {
+	result := *table
+	if content, ok := content.(*name); ok {
+		result.content = content
+	} else {
+		result.content = nil
+	}
+	result.rowid = rowid
+	return &result
+}
+
+// Set the tokenizer, for example "porter unicode61"
+func (table *fts5) WithTokenizer(tokenizer string) FTS5 {
+	result := *table
+	result.tokenizer = tokenizer
+	return &result
+}
+
+// Return the triggers which keep the index synchronised with the
+// external content table
+func (table *fts5) Triggers() []Query {
+	if table.content == nil {
+		return nil
+	}
+
+	// Column names and values from the new and old rows
+	cols := []string{"rowid"}
+	newcols := []string{"new." + QuoteIdentifier(table.contentRowId())}
+	oldcols := []string{"old." + QuoteIdentifier(table.contentRowId())}
+	for _, col := range table.col {
+		cols = append(cols, QuoteIdentifier(col.v))
+		newcols = append(newcols, "new."+QuoteIdentifier(col.v))
+		oldcols = append(oldcols, "old."+QuoteIdentifier(col.v))
+	}
+
+	// Statements to insert and delete rows from the index. Trigger bodies
+	// cannot qualify table names, so the schema is only on CREATE TRIGGER
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", QuoteIdentifier(table.v), strings.Join(cols, ", "), strings.Join(newcols, ", "))
+	del := fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (%s, %s);", QuoteIdentifier(table.v), QuoteIdentifier(table.v), strings.Join(cols, ", "), Quote("delete"), strings.Join(oldcols, ", "))
+
+	// Return the triggers
+	return []Query{
+		Q(table.trigger("ai", "INSERT") + " BEGIN " + insert + " END"),
+		Q(table.trigger("ad", "DELETE") + " BEGIN " + del + " END"),
+		Q(table.trigger("au", "UPDATE") + " BEGIN " + del + " " + insert + " END"),
+	}
+}
+
+// Return an expression which matches rows with a full-text query
+func (table *fts5) Match(query string) Query {
+	return Q(QuoteIdentifier(table.v) + " MATCH " + Quote(query))
+}
+
+// Return the bm25() rank expression, with optional weights for each column
+func (table *fts5) Rank(weights ...float64) Query {
+	args := []string{QuoteIdentifier(table.v)}
+	for _, w := range weights {
+		args = append(args, strconv.FormatFloat(w, 'f', -1, 64))
+	}
+	return Q("bm25(" + strings.Join(args, ", ") + ")")
+}
+
+// Return an expression which highlights matches in a column
+func (table *fts5) Highlight(col int, before, after string) Query {
+	return Q(fmt.Sprintf("highlight(%s, %d, %s, %s)", QuoteIdentifier(table.v), col, Quote(before), Quote(after)))
+}
+
+// Return an expression which returns a fragment of text from a column
+func (table *fts5) Snippet(col int, before, after, ellipsis string, tokens int) Query {
+	return Q(fmt.Sprintf("snippet(%s, %d, %s, %s, %s, %d)", QuoteIdentifier(table.v), col, Quote(before), Quote(after), Quote(ellipsis), tokens))
+}
+
+// Return a query which returns rows which match a full-text query,
+// ordered by rank
+func (table *fts5) Search(query string, expr ...Query) Query {
+	var str string
+
+	// Columns
+	fts := QuoteIdentifier(table.v)
+	cols := []string{fts + ".rowid"}
+	if table.content != nil {
+		cols = append(cols, QuoteIdentifier(table.content.v)+".*")
+	} else {
+		for _, col := range table.col {
+			cols = append(cols, fts+"."+QuoteIdentifier(col.v))
+		}
+	}
+	for _, e := range expr {
+		cols = append(cols, e.Query())
+	}
+	str += "SELECT " + strings.Join(cols, ", ") + " FROM " + table.SchemaName()
+
+	// Join with the content table
+	if table.content != nil {
+		str += " JOIN " + table.content.SchemaName() + " ON " + QuoteIdentifier(table.content.v) + "." + QuoteIdentifier(table.contentRowId()) + " = " + fts + ".rowid"
+	}
+
+	// Match and order by rank
+	str += " WHERE " + table.Match(query).Query() + " ORDER BY " + table.Rank().Query()
+
+	// Return the query
+	return Q(str)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+// Query returns the SQL query that can be executed
+func (table *fts5) Query() string {
+	var str string
+	str += "CREATE VIRTUAL TABLE "
+	if table.f.Is(IF_NOT_EXISTS) {
+		str += "IF NOT EXISTS "
+	}
+	str += table.name.SchemaName() + " USING fts5"
+
+	// Columns and options
+	args := make([]string, 0, len(table.col)+3)
+	for _, col := range table.col {
+		if strings.EqualFold(col.decltype, ftsUnindexed) {
+			args = append(args, QuoteIdentifier(col.v)+" "+ftsUnindexed)
+		} else {
+			args = append(args, QuoteIdentifier(col.v))
+		}
+	}
+	if table.content != nil {
+		args = append(args, "content="+Quote(table.content.v))
+		if table.rowid != "" {
+			args = append(args, "content_rowid="+Quote(table.rowid))
+		}
+	}
+	if table.tokenizer != "" {
+		args = append(args, "tokenize="+Quote(table.tokenizer))
+	}
+
+	// Return the query
+	return str + "(" + strings.Join(args, ", ") + ")"
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return the column in the content table which is used as the rowid
+func (table *fts5) contentRowId() string {
+	if table.rowid != "" {
+		return table.rowid
+	} else {
+		return "rowid"
+	}
+}
+
+// Return the CREATE TRIGGER clause for a trigger on the content table
+func (table *fts5) trigger(suffix, event string) string {
+	var str string
+	str += "CREATE TRIGGER "
+	if table.f.Is(IF_NOT_EXISTS) {
+		str += "IF NOT EXISTS "
+	}
+	trigger := &name{query: query{v: table.v + "_" + suffix}, schema: table.schema}
+	return str + trigger.SchemaName() + " AFTER " + event + " ON " + QuoteIdentifier(table.content.v)
+}
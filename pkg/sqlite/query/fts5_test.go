@@ -0,0 +1,69 @@
+package query_test
+
+import (
+	"testing"
+
+	// Packages
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace import
+	. "github.com/mutablelogic/go-accessory"
+	. "github.com/mutablelogic/go-accessory/pkg/sqlite/query"
+)
+
+func Test_FTS5_000(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		In     Query
+		String string
+	}{
+		{N("a").FTS5(), `CREATE VIRTUAL TABLE a USING fts5()`},
+		{N("a").WithSchema("b").FTS5(N("x")), `CREATE VIRTUAL TABLE b.a USING fts5(x)`},
+		{N("a").FTS5(N("x"), N("y").WithType("UNINDEXED")).With(IF_NOT_EXISTS), `CREATE VIRTUAL TABLE IF NOT EXISTS a USING fts5(x, y UNINDEXED)`},
+		{N("a").FTS5(N("x")).WithTokenizer("porter unicode61"), `CREATE VIRTUAL TABLE a USING fts5(x, tokenize='porter unicode61')`},
+		{N("a").FTS5(N("x")).WithContent(N("c"), "id"), `CREATE VIRTUAL TABLE a USING fts5(x, content='c', content_rowid='id')`},
+		{N("a").FTS5(N("x")).WithContent(N("c"), ""), `CREATE VIRTUAL TABLE a USING fts5(x, content='c')`},
+	}
+	for _, test := range tests {
+		assert.Equal(test.String, test.In.Query())
+	}
+}
+
+func Test_FTS5_001(t *testing.T) {
+	assert := assert.New(t)
+	fts := N("a").FTS5(N("x"), N("y"))
+	tests := []struct {
+		In     Query
+		String string
+	}{
+		{fts.Match("it's"), `a MATCH 'it''s'`},
+		{fts.Rank(), `bm25(a)`},
+		{fts.Rank(10, 0.5), `bm25(a, 10, 0.5)`},
+		{fts.Highlight(0, "<b>", "</b>"), `highlight(a, 0, '<b>', '</b>')`},
+		{fts.Snippet(1, "[", "]", "...", 16), `snippet(a, 1, '[', ']', '...', 16)`},
+		{fts.Search("q"), `SELECT a.rowid, a.x, a.y FROM a WHERE a MATCH 'q' ORDER BY bm25(a)`},
+		{fts.Search("q", fts.Highlight(0, "[", "]")), `SELECT a.rowid, a.x, a.y, highlight(a, 0, '[', ']') FROM a WHERE a MATCH 'q' ORDER BY bm25(a)`},
+		{fts.WithContent(N("c"), "id").Search("q"), `SELECT a.rowid, c.* FROM a JOIN c ON c.id = a.rowid WHERE a MATCH 'q' ORDER BY bm25(a)`},
+	}
+	for _, test := range tests {
+		assert.Equal(test.String, test.In.Query())
+	}
+}
+
+func Test_FTS5_002(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(N("a").FTS5(N("x")).Triggers())
+
+	triggers := N("a").FTS5(N("x")).WithContent(N("c"), "id").Triggers()
+	assert.Len(triggers, 3)
+	assert.Equal(`CREATE TRIGGER a_ai AFTER INSERT ON c BEGIN INSERT INTO a (rowid, x) VALUES (new.id, new.x); END`, triggers[0].Query())
+	assert.Equal(`CREATE TRIGGER a_ad AFTER DELETE ON c BEGIN INSERT INTO a (a, rowid, x) VALUES ('delete', old.id, old.x); END`, triggers[1].Query())
+	assert.Equal(`CREATE TRIGGER a_au AFTER UPDATE ON c BEGIN INSERT INTO a (a, rowid, x) VALUES ('delete', old.id, old.x); INSERT INTO a (rowid, x) VALUES (new.id, new.x); END`, triggers[2].Query())
+
+	// The schema is only used to qualify the trigger name
+	triggers = N("a").WithSchema("s").FTS5(N("x")).WithContent(N("c"), "id").Triggers()
+	assert.Len(triggers, 3)
+	assert.Equal(`CREATE TRIGGER s.a_ai AFTER INSERT ON c BEGIN INSERT INTO a (rowid, x) VALUES (new.id, new.x); END`, triggers[0].Query())
+	assert.Equal(`CREATE TRIGGER s.a_ad AFTER DELETE ON c BEGIN INSERT INTO a (a, rowid, x) VALUES ('delete', old.id, old.x); END`, triggers[1].Query())
+	assert.Equal(`CREATE TRIGGER s.a_au AFTER UPDATE ON c BEGIN INSERT INTO a (a, rowid, x) VALUES ('delete', old.id, old.x); INSERT INTO a (rowid, x) VALUES (new.id, new.x); END`, triggers[2].Query())
+}
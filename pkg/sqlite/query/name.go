@@ -15,6 +15,7 @@ type name struct {
 	schema   string
 	alias    string // For use in FROM clauses
 	decltype string // For use in CREATE TABLE clauses
+	collate  string // For use in CREATE TABLE and ORDER BY clauses
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -34,15 +35,19 @@ func N(v string, f ...QueryFlag) Name {
 // METHODS
 
 func (n *name) WithSchema(schema string) Name {
-	return &name{n.query, schema, n.alias, n.decltype}
+	return &name{n.query, schema, n.alias, n.decltype, n.collate}
 }
 
-func (n *name) WithAlias(alias string) Name {
-	return &name{n.query, n.schema, alias, n.decltype}
+func (n *name) As(alias string) Name {
+	return &name{n.query, n.schema, alias, n.decltype, n.collate}
 }
 
 func (n *name) WithType(decltype string) Name {
-	return &name{n.query, n.schema, n.alias, decltype}
+	return &name{n.query, n.schema, n.alias, decltype, n.collate}
+}
+
+func (n *name) WithCollation(collate string) Name {
+	return &name{n.query, n.schema, n.alias, n.decltype, collate}
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -67,6 +72,9 @@ func (name *name) Query() string {
 	if name.decltype != "" {
 		str += " " + name.decltype
 	}
+	if name.collate != "" {
+		str += " COLLATE " + QuoteIdentifier(name.collate)
+	}
 
 	// For column definitions, add in NOT NULL, UNIQUE or PRIMARY KEY clauses
 	// For sort clause, add in ASC or DESC
@@ -28,6 +28,9 @@ func Test_Name_000(t *testing.T) {
 		{N("x", ASC), `x ASC`},
 		{N("x", DESC, ASC), `x ASC`}, // defaults to ASC, not DESC
 		{N("x", PRIMARY_KEY, AUTO_INCREMENT), `x PRIMARY KEY AUTOINCREMENT`},
+		{N("x").WithCollation("nocase"), `x COLLATE nocase`},
+		{N("x", DESC).WithCollation("natsort"), `x COLLATE natsort DESC`},
+		{N("x", NOT_NULL).WithType("TEXT").WithCollation("en-GB"), `x TEXT COLLATE "en-GB" NOT NULL`},
 	}
 	for _, test := range tests {
 		assert.Equal(test.String, test.In.Query())
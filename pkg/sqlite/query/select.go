@@ -0,0 +1,183 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-accessory"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type selectQuery struct {
+	query
+	expr   []string
+	from   []string
+	where  []string
+	group  []string
+	having []string
+	order  []string
+	limit  uint
+	offset uint
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// S returns a new SELECT statement with the selected columns, which are
+// column names as strings, names or expressions. All columns are selected
+// when there are no arguments.
+func S(v ...any) Select {
+	return &selectQuery{expr: columns(v)}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Append flags to the statement
+func (s *selectQuery) With(f QueryFlag) Query {
+	result := *s
+	result.f |= f
+	return &result
+}
+
+// Return unique rows
+func (s *selectQuery) Distinct() Select {
+	result := *s
+	result.f |= DISTINCT
+	return &result
+}
+
+// Set the table sources, which are table names as strings or names
+func (s *selectQuery) From(v ...any) Select {
+	result := *s
+	result.from = columns(v)
+	return &result
+}
+
+// Set the expressions which rows must match
+func (s *selectQuery) Where(v ...Query) Select {
+	result := *s
+	result.where = exprs(v)
+	return &result
+}
+
+// Set the columns or expressions which rows are grouped by
+func (s *selectQuery) GroupBy(v ...any) Select {
+	result := *s
+	result.group = columns(v)
+	return &result
+}
+
+// Set the expressions which groups must match
+func (s *selectQuery) Having(v ...Query) Select {
+	result := *s
+	result.having = exprs(v)
+	return &result
+}
+
+// Set the sort order
+func (s *selectQuery) OrderBy(v ...any) Select {
+	result := *s
+	result.order = columns(v)
+	return &result
+}
+
+// Set the maximum number of rows returned
+func (s *selectQuery) Limit(limit uint) Select {
+	result := *s
+	result.limit = limit
+	return &result
+}
+
+// Set the number of rows which are skipped
+func (s *selectQuery) Offset(offset uint) Select {
+	result := *s
+	result.offset = offset
+	return &result
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+// Query returns the SQL query that can be executed
+func (s *selectQuery) Query() string {
+	var str string
+	str += "SELECT"
+	if s.f.Is(DISTINCT) {
+		str += " " + DISTINCT.String()
+	}
+	if len(s.expr) == 0 {
+		str += " *"
+	} else {
+		str += " " + strings.Join(s.expr, ", ")
+	}
+	if len(s.from) > 0 {
+		str += " FROM " + strings.Join(s.from, ", ")
+	}
+	if len(s.where) > 0 {
+		str += " WHERE " + and(s.where)
+	}
+	if len(s.group) > 0 {
+		str += " GROUP BY " + strings.Join(s.group, ", ")
+	}
+	if len(s.having) > 0 {
+		str += " HAVING " + and(s.having)
+	}
+	if len(s.order) > 0 {
+		str += " ORDER BY " + strings.Join(s.order, ", ")
+	}
+
+	// An offset without a limit requires a negative limit
+	if s.limit > 0 {
+		str += " LIMIT " + strconv.FormatUint(uint64(s.limit), 10)
+	} else if s.offset > 0 {
+		str += " LIMIT -1"
+	}
+	if s.offset > 0 {
+		str += " OFFSET " + strconv.FormatUint(uint64(s.offset), 10)
+	}
+
+	// Return success
+	return str
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// columns returns names from strings, and expressions from other values
+func columns(v []any) []string {
+	result := make([]string, 0, len(v))
+	for _, v := range v {
+		if name, ok := v.(string); ok {
+			result = append(result, N(name).Query())
+		} else {
+			result = append(result, expr(v))
+		}
+	}
+	return result
+}
+
+// exprs returns expressions from queries
+func exprs(v []Query) []string {
+	result := make([]string, 0, len(v))
+	for _, v := range v {
+		result = append(result, expr(v))
+	}
+	return result
+}
+
+// and combines expressions with AND, adding parentheses when there is
+// more than one expression
+func and(v []string) string {
+	if len(v) == 1 {
+		return v[0]
+	}
+	result := make([]string, len(v))
+	for i, v := range v {
+		result[i] = "(" + v + ")"
+	}
+	return strings.Join(result, " AND ")
+}
@@ -0,0 +1,75 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	// Packages
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace import
+	. "github.com/mutablelogic/go-accessory"
+	. "github.com/mutablelogic/go-accessory/pkg/sqlite/query"
+)
+
+func Test_Expr_000(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		In     Query
+		String string
+	}{
+		{E(nil), `NULL`},
+		{E(1), `1`},
+		{E(-1.5), `-1.5`},
+		{E(true), `TRUE`},
+		{E("it's"), `'it''s'`},
+		{E([]byte{0xCA, 0xFE}), `X'CAFE'`},
+		{E(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), `'2023-01-02T03:04:05Z'`},
+		{E(N("x")), `x`},
+		{E(Q("count(*)")), `count(*)`},
+		{E(S(E(1))), `(SELECT 1)`},
+	}
+	for _, test := range tests {
+		assert.Equal(test.String, test.In.Query())
+	}
+}
+
+func Test_Select_000(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		In     Query
+		String string
+	}{
+		{S(), `SELECT *`},
+		{S(E(1)), `SELECT 1`},
+		{S("id", "name").From("users"), `SELECT id, name FROM users`},
+		{S().From(N("users")).With(DISTINCT), `SELECT DISTINCT * FROM users`},
+		{S("x").Distinct().From("a"), `SELECT DISTINCT x FROM a`},
+		{S().From("a").Where(Q("x IN " + E(S("y").From("b")).Query())), `SELECT * FROM a WHERE x IN (SELECT y FROM b)`},
+		{S().From(S("x").From("a")), `SELECT * FROM (SELECT x FROM a)`},
+		{S(N("name").As("uid")).From("users"), `SELECT name AS uid FROM users`},
+		{S("x y").From(N("a").WithSchema("main").As("t"), "b"), `SELECT "x y" FROM main.a AS t, b`},
+		{S().From("a").Where(Q("x > 1")), `SELECT * FROM a WHERE x > 1`},
+		{S().From("a").Where(Q("x > 1"), Q("y = 2 OR z = 3")), `SELECT * FROM a WHERE (x > 1) AND (y = 2 OR z = 3)`},
+		{S("x", Q("count(*)")).From("a").GroupBy("x").Having(Q("count(*) > 1")), `SELECT x, count(*) FROM a GROUP BY x HAVING count(*) > 1`},
+		{S().From("a").OrderBy(N("x", DESC), "y"), `SELECT * FROM a ORDER BY x DESC, y`},
+		{S().From("a").OrderBy(N("x", ASC).WithCollation("nocase")), `SELECT * FROM a ORDER BY x COLLATE nocase ASC`},
+		{S().From("a").Limit(10), `SELECT * FROM a LIMIT 10`},
+		{S().From("a").Limit(10).Offset(20), `SELECT * FROM a LIMIT 10 OFFSET 20`},
+		{S().From("a").Offset(20), `SELECT * FROM a LIMIT -1 OFFSET 20`},
+		{S().From("a").Where(Q("x > 1")).Where(Q("y > 1")), `SELECT * FROM a WHERE y > 1`},
+	}
+	for _, test := range tests {
+		assert.Equal(test.String, test.In.Query())
+	}
+}
+
+func Test_Select_001(t *testing.T) {
+	assert := assert.New(t)
+
+	// Each clause returns a new statement
+	a := S("x").From("a")
+	b := a.Where(Q("x > 1"))
+	assert.Equal(`SELECT x FROM a`, a.Query())
+	assert.Equal(`SELECT x FROM a WHERE x > 1`, b.Query())
+}
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+extern int go_authorizer_hook(void* userInfo, int op, char* a1, char* a2, char* a3, char* a4);
+static inline int _sqlite3_set_authorizer(sqlite3* db, uintptr_t userInfo) {
+	if (userInfo == 0) {
+		return sqlite3_set_authorizer(db, NULL, NULL);
+	}
+	return sqlite3_set_authorizer(db, (int (*)(void*, int, const char*, const char*, const char*, const char*))(go_authorizer_hook), (void* )(userInfo));
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// AuthorizerHookFunc is invoked as SQL statements are being compiled. The
+// arguments depend on the action: for table actions the first argument is the
+// table name, the second is the column name for SQLITE_READ and SQLITE_UPDATE,
+// the third is the schema name and the fourth is the name of the trigger or
+// view responsible for the access. The return value should be SQLITE_ALLOW,
+// SQLITE_DENY or SQLITE_IGNORE.
+type AuthorizerHookFunc func(SQAction, [4]string) SQAuth
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// Keys for authorizers registered on each connection
+var authorizers = struct {
+	sync.Mutex
+	m map[*Conn]uintptr
+}{m: make(map[*Conn]uintptr)}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// SetAuthorizer sets a function which is invoked as SQL statements are being
+// compiled, and which can allow, deny or ignore each action. Any previous
+// authorizer is replaced. If fn is nil, then the authorizer is removed.
+// Statements which are already compiled are not affected.
+func (c *Conn) SetAuthorizer(fn AuthorizerHookFunc) error {
+	authorizers.Lock()
+	defer authorizers.Unlock()
+
+	var key uintptr
+	if fn != nil {
+		key = cb.add(fn)
+	}
+	if err := SQError(C._sqlite3_set_authorizer((*C.sqlite3)(c), C.uintptr_t(key))); err != SQLITE_OK {
+		cb.remove(key)
+		return newError((*C.sqlite3)(c), err, "")
+	}
+	if prev, exists := authorizers.m[c]; exists {
+		cb.remove(prev)
+		delete(authorizers.m, c)
+	}
+	if key != 0 {
+		authorizers.m[c] = key
+	}
+
+	// Return success
+	return nil
+}
+
+// ReadOnlyAuthorizer returns an authorizer which allows queries, and denies
+// changes to the database except for inserts, updates and deletes on the
+// named tables. Schema changes, ATTACH and PRAGMA statements are denied.
+func ReadOnlyAuthorizer(tables ...string) AuthorizerHookFunc {
+	return func(action SQAction, args [4]string) SQAuth {
+		switch action {
+		case SQLITE_SELECT, SQLITE_READ, SQLITE_FUNCTION, SQLITE_RECURSIVE, SQLITE_TRANSACTION, SQLITE_SAVEPOINT:
+			return SQLITE_ALLOW
+		case SQLITE_INSERT, SQLITE_UPDATE, SQLITE_DELETE:
+			for _, table := range tables {
+				if strings.EqualFold(table, args[0]) {
+					return SQLITE_ALLOW
+				}
+			}
+		}
+		return SQLITE_DENY
+	}
+}
+
+// DenyAuthorizer returns an authorizer which denies the actions, and
+// allows all others
+func DenyAuthorizer(actions ...SQAction) AuthorizerHookFunc {
+	return func(action SQAction, args [4]string) SQAuth {
+		for _, v := range actions {
+			if v == action {
+				return SQLITE_DENY
+			}
+		}
+		return SQLITE_ALLOW
+	}
+}
+
+// ChainAuthorizer returns an authorizer which calls each authorizer in turn,
+// and returns the first result which is not SQLITE_ALLOW
+func ChainAuthorizer(fns ...AuthorizerHookFunc) AuthorizerHookFunc {
+	return func(action SQAction, args [4]string) SQAuth {
+		for _, fn := range fns {
+			if result := fn(action, args); result != SQLITE_ALLOW {
+				return result
+			}
+		}
+		return SQLITE_ALLOW
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_authorizer_hook
+func go_authorizer_hook(userInfo unsafe.Pointer, op C.int, a1, a2, a3, a4 *C.char) (rc C.int) {
+	// Deny the action if the authorizer panics
+	defer func() {
+		if r := recover(); r != nil {
+			rc = C.int(SQLITE_DENY)
+		}
+	}()
+	if fn, ok := cb.get(uintptr(userInfo)).(AuthorizerHookFunc); ok {
+		return C.int(fn(SQAction(op), [4]string{C.GoString(a1), C.GoString(a2), C.GoString(a3), C.GoString(a4)}))
+	}
+	return C.int(SQLITE_DENY)
+}
@@ -0,0 +1,64 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Authorizer_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	assert.NoError(db.Exec("CREATE TABLE a (x); CREATE TABLE b (y)"))
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		assert.NoError(db.SetAuthorizer(sqlite.ReadOnlyAuthorizer("B")))
+		defer db.SetAuthorizer(nil)
+
+		assert.NoError(db.Exec("SELECT * FROM a, b"))
+		assert.NoError(db.Exec("INSERT INTO b VALUES (1)"))
+		assert.NoError(db.Exec("UPDATE b SET y = 2"))
+		assert.Error(db.Exec("INSERT INTO a VALUES (1)"))
+		assert.Error(db.Exec("DELETE FROM a"))
+		assert.Error(db.Exec("CREATE TABLE c (z)"))
+		assert.Error(db.Exec("DROP TABLE b"))
+		assert.Error(db.Exec("PRAGMA user_version = 1"))
+		assert.Error(db.Exec("ATTACH DATABASE ':memory:' AS other"))
+	})
+
+	t.Run("Deny", func(t *testing.T) {
+		var actions []sqlite.SQAction
+		assert.NoError(db.SetAuthorizer(sqlite.ChainAuthorizer(func(action sqlite.SQAction, args [4]string) sqlite.SQAuth {
+			actions = append(actions, action)
+			return sqlite.SQLITE_ALLOW
+		}, sqlite.DenyAuthorizer(sqlite.SQLITE_ATTACH, sqlite.SQLITE_PRAGMA))))
+		defer db.SetAuthorizer(nil)
+
+		assert.NoError(db.Exec("INSERT INTO a VALUES (1)"))
+		assert.Contains(actions, sqlite.SQLITE_INSERT)
+		assert.Error(db.Exec("PRAGMA user_version = 1"))
+		assert.Error(db.Exec("ATTACH DATABASE ':memory:' AS other"))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(db.SetAuthorizer(sqlite.DenyAuthorizer(sqlite.SQLITE_PRAGMA)))
+		assert.Error(db.Exec("PRAGMA user_version = 1"))
+		assert.NoError(db.SetAuthorizer(nil))
+		assert.NoError(db.Exec("PRAGMA user_version = 1"))
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.NoError(db.SetAuthorizer(func(sqlite.SQAction, [4]string) sqlite.SQAuth {
+			panic("authorizer")
+		}))
+		defer db.SetAuthorizer(nil)
+		assert.Error(db.Exec("INSERT INTO a VALUES (2)"))
+	})
+}
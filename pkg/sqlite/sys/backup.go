@@ -0,0 +1,212 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+	"unsafe"
+
+	// Modules
+	multierror "github.com/hashicorp/go-multierror"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type Backup C.sqlite3_backup
+
+// BackupProgressFunc is invoked after each backup step with the number of pages
+// remaining to be copied and the total number of pages in the source database
+type BackupProgressFunc func(remaining, total int)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Default number of pages to copy in each backup step
+	DefaultBackupPages = 100
+
+	// Delay between backup steps, so that writers are not blocked
+	backupStepDelay = 10 * time.Millisecond
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// OpenBackup starts copying the source schema of the connection to the destination
+// connection and schema. If either schema is empty, the main schema is used.
+func (c *Conn) OpenBackup(dest *Conn, destSchema, schema string) (*Backup, error) {
+	var cDest, cSource *C.char
+
+	// Check arguments
+	if dest == nil || dest == c {
+		return nil, ErrBadParameter.With("OpenBackup")
+	}
+
+	// Set schema to default if empty string
+	if destSchema == "" {
+		destSchema = DefaultSchema
+	}
+	if schema == "" {
+		schema = DefaultSchema
+	}
+
+	// Populate CStrings
+	cDest = C.CString(destSchema)
+	defer C.free(unsafe.Pointer(cDest))
+	cSource = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSource))
+
+	// Call sqlite3_backup_init, where the error is set on the destination
+	b := C.sqlite3_backup_init((*C.sqlite3)(dest), cDest, (*C.sqlite3)(c), cSource)
+	if b == nil {
+		err := SQError(C.sqlite3_errcode((*C.sqlite3)(dest)))
+		return nil, newError((*C.sqlite3)(dest), err, "")
+	}
+
+	// Return success
+	return (*Backup)(b), nil
+}
+
+// Finish releases resources associated with the backup
+func (b *Backup) Finish() error {
+	if err := SQError(C.sqlite3_backup_finish((*C.sqlite3_backup)(b))); err != SQLITE_OK {
+		return err
+	} else {
+		return nil
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Step copies up to n pages from source to destination, or all remaining pages
+// if n is negative. Returns true when all pages have been copied. The errors
+// SQLITE_BUSY and SQLITE_LOCKED can be retried later.
+func (b *Backup) Step(n int) (bool, error) {
+	switch err := SQError(C.sqlite3_backup_step((*C.sqlite3_backup)(b), C.int(n))); err {
+	case SQLITE_OK:
+		return false, nil
+	case SQLITE_DONE:
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
+// Remaining returns the number of pages still to be copied after the last step
+func (b *Backup) Remaining() int {
+	return int(C.sqlite3_backup_remaining((*C.sqlite3_backup)(b)))
+}
+
+// PageCount returns the total number of pages in the source database after
+// the last step
+func (b *Backup) PageCount() int {
+	return int(C.sqlite3_backup_pagecount((*C.sqlite3_backup)(b)))
+}
+
+// Backup copies the main schema to the main schema of the destination connection,
+// copying the given number of pages at each step so that writers on the source
+// database are not blocked for long. If the source database is modified by another
+// connection during the backup, the backup restarts. The progress function, if not
+// nil, is called after each step. Cancelling the context aborts the backup.
+func (c *Conn) Backup(ctx context.Context, dest *Conn, pages int, fn BackupProgressFunc) error {
+	var result error
+
+	// Set default number of pages
+	if pages == 0 {
+		pages = DefaultBackupPages
+	}
+
+	// Start the backup
+	backup, err := c.OpenBackup(dest, DefaultSchema, DefaultSchema)
+	if err != nil {
+		return err
+	}
+
+	// Copy pages until done, or context is cancelled
+	var stepErr error
+FOR_LOOP:
+	for {
+		done, err := backup.Step(pages)
+		if err != nil && !isRetryable(err) {
+			stepErr = err
+			result = multierror.Append(result, err)
+			break FOR_LOOP
+		}
+		if fn != nil {
+			fn(backup.Remaining(), backup.PageCount())
+		}
+		if done {
+			break FOR_LOOP
+		}
+		select {
+		case <-ctx.Done():
+			result = multierror.Append(result, ctx.Err())
+			break FOR_LOOP
+		case <-time.After(backupStepDelay):
+			continue
+		}
+	}
+
+	// Release resources. Finish returns the same error as a failed step,
+	// so it is only reported when the steps succeeded
+	if err := backup.Finish(); err != nil && stepErr == nil {
+		result = multierror.Append(result, err)
+	}
+
+	// Return any errors
+	return result
+}
+
+// BackupPath copies the main schema to a database file at the given path,
+// which is created if it does not exist. See Backup for details.
+func (c *Conn) BackupPath(ctx context.Context, path string, pages int, fn BackupProgressFunc) error {
+	var result error
+
+	// Open the destination
+	dest, err := OpenPath(path, DefaultFlags, "")
+	if err != nil {
+		return err
+	}
+
+	// Perform the backup
+	if err := c.Backup(ctx, dest, pages, fn); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	// Close the destination
+	if err := dest.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	// Return any errors
+	return result
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// isRetryable returns true if the error is SQLITE_BUSY or SQLITE_LOCKED,
+// including extended error codes
+func isRetryable(err error) bool {
+	if err, ok := err.(SQError); ok {
+		switch err & 0xFF {
+		case SQLITE_BUSY, SQLITE_LOCKED:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	// Packages
+	multierror "github.com/hashicorp/go-multierror"
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Backup_001(t *testing.T) {
+	assert := assert.New(t)
+	src, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer src.Close()
+
+	// Create a table with some data
+	assert.NoError(src.Exec("CREATE TABLE test (a INTEGER PRIMARY KEY, b TEXT)"))
+	for i := 0; i < 1000; i++ {
+		assert.NoError(src.Exec(fmt.Sprintf("INSERT INTO test (b) VALUES ('value %d')", i)))
+	}
+
+	t.Run("001", func(t *testing.T) {
+		dest, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+		assert.NoError(err)
+		defer dest.Close()
+
+		// Backup in steps of one page
+		steps := 0
+		assert.NoError(src.Backup(context.Background(), dest, 1, func(remaining, total int) {
+			assert.LessOrEqual(remaining, total)
+			steps++
+		}))
+		assert.Greater(steps, 1)
+
+		// The table exists in the destination
+		assert.Error(dest.Exec("CREATE TABLE test (a INTEGER PRIMARY KEY, b TEXT)"))
+	})
+
+	t.Run("002", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.sqlite")
+		assert.NoError(src.BackupPath(context.Background(), path, 0, nil))
+
+		dest, err := sqlite.OpenPath(path, sqlite.SQLITE_OPEN_READWRITE, "")
+		assert.NoError(err)
+		defer dest.Close()
+		assert.Error(dest.Exec("CREATE TABLE test (a INTEGER PRIMARY KEY, b TEXT)"))
+	})
+
+	t.Run("003", func(t *testing.T) {
+		dest, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+		assert.NoError(err)
+		defer dest.Close()
+
+		// Cancelled context aborts the backup
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(src.Backup(ctx, dest, 1, nil), context.Canceled)
+	})
+
+	t.Run("004", func(t *testing.T) {
+		assert.Error(src.Backup(context.Background(), src, 0, nil))
+	})
+
+	t.Run("005", func(t *testing.T) {
+		dest, err := sqlite.OpenPath(filepath.Join(t.TempDir(), "test.sqlite"), sqlite.SQLITE_OPEN_CREATE, "")
+		assert.NoError(err)
+		defer dest.Close()
+
+		// Page sizes cannot change in WAL mode
+		assert.NoError(dest.Exec("PRAGMA page_size = 8192"))
+		_, err = dest.SetJournalMode("", sqlite.JournalWAL)
+		assert.NoError(err)
+		assert.NoError(dest.Exec("CREATE TABLE other (a)"))
+
+		// A failed step is reported once
+		err = src.Backup(context.Background(), dest, 0, nil)
+		assert.ErrorIs(err, sqlite.SQLITE_READONLY)
+		if assert.IsType(&multierror.Error{}, err) {
+			assert.Len(err.(*multierror.Error).Errors, 1)
+		}
+	})
+}
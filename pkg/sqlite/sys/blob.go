@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"io"
+	"math"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Blob provides incremental I/O on a BLOB value. It implements io.Reader,
+// io.ReaderAt, io.Writer, io.WriterAt, io.Seeker and io.Closer. The size
+// of the BLOB cannot be changed through incremental I/O.
+type Blob struct {
+	db     *C.sqlite3
+	b      *C.sqlite3_blob
+	offset int64
+}
+
+// Ensure *Blob implements the I/O interfaces
+var _ io.ReadWriteSeeker = (*Blob)(nil)
+var _ io.ReaderAt = (*Blob)(nil)
+var _ io.WriterAt = (*Blob)(nil)
+var _ io.Closer = (*Blob)(nil)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// OpenBlob opens the BLOB in the given schema, table, column and rowid for
+// incremental I/O. If the schema is empty, the main schema is used.
+// The BLOB is opened read-only unless writable is true.
+func (c *Conn) OpenBlob(schema, table, column string, rowid int64, writable bool) (*Blob, error) {
+	var cSchema, cTable, cColumn *C.char
+	var b *C.sqlite3_blob
+
+	// Set schema to default if empty string
+	if schema == "" {
+		schema = DefaultSchema
+	}
+
+	// Populate CStrings
+	cSchema = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+	cTable = C.CString(table)
+	defer C.free(unsafe.Pointer(cTable))
+	cColumn = C.CString(column)
+	defer C.free(unsafe.Pointer(cColumn))
+
+	// Call sqlite3_blob_open
+	if err := SQError(C.sqlite3_blob_open((*C.sqlite3)(c), cSchema, cTable, cColumn, C.sqlite3_int64(rowid), C.int(boolToInt(writable)), &b)); err != SQLITE_OK {
+		if b != nil {
+			C.sqlite3_blob_close(b)
+		}
+		return nil, newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return &Blob{db: (*C.sqlite3)(c), b: b}, nil
+}
+
+// Close the BLOB
+func (b *Blob) Close() error {
+	if b.b == nil {
+		return nil
+	}
+	err := SQError(C.sqlite3_blob_close(b.b))
+	b.b = nil
+	if err != SQLITE_OK {
+		return newError(b.db, err, "")
+	} else {
+		return nil
+	}
+}
+
+// Reopen moves the BLOB to a different row in the same table, and resets
+// the offset to the start of the BLOB. This is faster than opening a new BLOB.
+func (b *Blob) Reopen(rowid int64) error {
+	if b.b == nil {
+		return ErrOutOfOrder.With("Reopen")
+	}
+	if err := SQError(C.sqlite3_blob_reopen(b.b, C.sqlite3_int64(rowid))); err != SQLITE_OK {
+		return newError(b.db, err, "")
+	}
+	b.offset = 0
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Size returns the size of the BLOB in bytes
+func (b *Blob) Size() int64 {
+	if b.b == nil {
+		return 0
+	}
+	return int64(C.sqlite3_blob_bytes(b.b))
+}
+
+// ReadAt reads len(p) bytes from the BLOB starting at offset off. It returns
+// io.EOF if fewer than len(p) bytes are read because the end of the BLOB
+// was reached.
+func (b *Blob) ReadAt(p []byte, off int64) (int, error) {
+	if b.b == nil {
+		return 0, ErrOutOfOrder.With("ReadAt")
+	} else if off < 0 {
+		return 0, ErrBadParameter.With("ReadAt: negative offset")
+	}
+
+	// Determine the number of bytes to read
+	size := b.Size()
+	if off >= size {
+		return 0, io.EOF
+	}
+	n := int64(len(p))
+	if off+n > size {
+		n = size - off
+	}
+
+	// Read the bytes
+	if n > 0 {
+		if err := SQError(C.sqlite3_blob_read(b.b, unsafe.Pointer(&p[0]), C.int(n), C.int(off))); err != SQLITE_OK {
+			return 0, newError(b.db, err, "")
+		}
+	}
+
+	// Return the number of bytes read
+	if n < int64(len(p)) {
+		return int(n), io.EOF
+	} else {
+		return int(n), nil
+	}
+}
+
+// WriteAt writes len(p) bytes to the BLOB starting at offset off. As the
+// size of the BLOB cannot be changed, it returns io.ErrShortWrite if fewer
+// than len(p) bytes are written because the end of the BLOB was reached.
+func (b *Blob) WriteAt(p []byte, off int64) (int, error) {
+	if b.b == nil {
+		return 0, ErrOutOfOrder.With("WriteAt")
+	} else if off < 0 {
+		return 0, ErrBadParameter.With("WriteAt: negative offset")
+	}
+
+	// Determine the number of bytes to write
+	size := b.Size()
+	if off >= size && len(p) > 0 {
+		return 0, io.ErrShortWrite
+	}
+	n := int64(len(p))
+	if off+n > size {
+		if n = size - off; n < 0 {
+			n = 0
+		}
+	}
+
+	// Write the bytes
+	if n > 0 {
+		if err := SQError(C.sqlite3_blob_write(b.b, unsafe.Pointer(&p[0]), C.int(n), C.int(off))); err != SQLITE_OK {
+			return 0, newError(b.db, err, "")
+		}
+	}
+
+	// Return the number of bytes written
+	if n < int64(len(p)) {
+		return int(n), io.ErrShortWrite
+	} else {
+		return int(n), nil
+	}
+}
+
+// Read reads up to len(p) bytes from the current offset
+func (b *Blob) Read(p []byte) (int, error) {
+	n, err := b.ReadAt(p, b.offset)
+	b.offset += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// Write writes len(p) bytes at the current offset
+func (b *Blob) Write(p []byte) (int, error) {
+	n, err := b.WriteAt(p, b.offset)
+	b.offset += int64(n)
+	return n, err
+}
+
+// Seek sets the offset for the next Read or Write
+func (b *Blob) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		// Offset is from the start
+	case io.SeekCurrent:
+		offset += b.offset
+	case io.SeekEnd:
+		offset += b.Size()
+	default:
+		return 0, ErrBadParameter.With("Seek: invalid whence")
+	}
+	if offset < 0 || offset > math.MaxInt32 {
+		return 0, ErrBadParameter.With("Seek: invalid offset")
+	}
+	b.offset = offset
+	return offset, nil
+}
@@ -0,0 +1,101 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Blob_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Create a table with some blobs
+	assert.NoError(db.Exec("CREATE TABLE test (data BLOB)"))
+	assert.NoError(db.Exec("INSERT INTO test (data) VALUES (zeroblob(10)), (zeroblob(20))"))
+
+	t.Run("001", func(t *testing.T) {
+		blob, err := db.OpenBlob("", "test", "data", 1, true)
+		assert.NoError(err)
+		defer blob.Close()
+		assert.Equal(int64(10), blob.Size())
+
+		// Write and read back
+		n, err := blob.WriteAt([]byte("hello"), 5)
+		assert.NoError(err)
+		assert.Equal(5, n)
+		buf := make([]byte, 10)
+		n, err = blob.ReadAt(buf, 0)
+		assert.NoError(err)
+		assert.Equal(10, n)
+		assert.Equal([]byte("\x00\x00\x00\x00\x00hello"), buf)
+
+		// Read past the end
+		n, err = blob.ReadAt(buf, 8)
+		assert.ErrorIs(err, io.EOF)
+		assert.Equal(2, n)
+
+		// Write past the end
+		n, err = blob.WriteAt([]byte("world"), 8)
+		assert.ErrorIs(err, io.ErrShortWrite)
+		assert.Equal(2, n)
+
+		// Write nothing past the end
+		n, err = blob.WriteAt(nil, 20)
+		assert.NoError(err)
+		assert.Equal(0, n)
+	})
+
+	t.Run("002", func(t *testing.T) {
+		blob, err := db.OpenBlob(sqlite.DefaultSchema, "test", "data", 1, false)
+		assert.NoError(err)
+		defer blob.Close()
+
+		// Read using a reader
+		data, err := io.ReadAll(blob)
+		assert.NoError(err)
+		assert.Equal([]byte("\x00\x00\x00\x00\x00helwo"), data)
+
+		// Read-only
+		_, err = blob.WriteAt([]byte("x"), 0)
+		assert.Error(err)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		blob, err := db.OpenBlob("", "test", "data", 1, true)
+		assert.NoError(err)
+		defer blob.Close()
+
+		// Move to the second row and write with seek
+		assert.NoError(blob.Reopen(2))
+		assert.Equal(int64(20), blob.Size())
+		offset, err := blob.Seek(-5, io.SeekEnd)
+		assert.NoError(err)
+		assert.Equal(int64(15), offset)
+		n, err := io.Copy(blob, bytes.NewReader([]byte("abcde")))
+		assert.NoError(err)
+		assert.Equal(int64(5), n)
+
+		// Read back
+		buf := make([]byte, 5)
+		_, err = blob.ReadAt(buf, 15)
+		assert.NoError(err)
+		assert.Equal([]byte("abcde"), buf)
+
+		// Reopen missing row
+		assert.ErrorContains(blob.Reopen(3), "no such rowid")
+	})
+
+	t.Run("004", func(t *testing.T) {
+		_, err := db.OpenBlob("", "test", "other", 1, false)
+		assert.Error(err)
+	})
+}
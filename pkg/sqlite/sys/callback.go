@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"unsafe"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+// go_callback_destroy is passed to sqlite as the destructor for user data
+//
+//export go_callback_destroy
+func go_callback_destroy(userInfo unsafe.Pointer) {
+	cb.remove(uintptr(userInfo))
+}
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+extern int go_collation_compare(void* userInfo, int na, void* a, int nb, void* b);
+extern void go_callback_destroy(void* userInfo);
+
+static inline int _sqlite3_create_collation(sqlite3* db, const char* name, uintptr_t userInfo) {
+	return sqlite3_create_collation_v2(db, name, SQLITE_UTF8, (void* )(userInfo), (int (*)(void*, int, const void*, int, const void*))(go_collation_compare), go_callback_destroy);
+}
+static inline int _sqlite3_delete_collation(sqlite3* db, const char* name) {
+	return sqlite3_create_collation_v2(db, name, SQLITE_UTF8, NULL, NULL, NULL);
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// CollationFunc compares two strings, and returns a negative number if a < b,
+// zero if a == b and a positive number if a > b. It should always return the
+// same result given the same inputs.
+type CollationFunc func(a, b string) int
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// CreateCollation registers a collating sequence with a name, which can be
+// used in COLLATE clauses for column definitions, indexes and ORDER BY.
+// Collation names are case-insensitive. If fn is nil, then the collation is
+// removed.
+func (c *Conn) CreateCollation(name string, fn CollationFunc) error {
+	if name == "" {
+		return ErrBadParameter.With("CreateCollation")
+	}
+
+	var cName *C.char = C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	// Remove the collation
+	if fn == nil {
+		if err := SQError(C._sqlite3_delete_collation((*C.sqlite3)(c), cName)); err != SQLITE_OK {
+			return newError((*C.sqlite3)(c), err, "")
+		}
+		return nil
+	}
+
+	// The callback is removed by go_callback_destroy when the collation is
+	// replaced or removed, or the connection is closed. It is not called when
+	// the collation cannot be created.
+	key := cb.add(fn)
+	if err := SQError(C._sqlite3_create_collation((*C.sqlite3)(c), cName, C.uintptr_t(key))); err != SQLITE_OK {
+		cb.remove(key)
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_collation_compare
+func go_collation_compare(userInfo unsafe.Pointer, na C.int, a unsafe.Pointer, nb C.int, b unsafe.Pointer) (rc C.int) {
+	// Compare as equal if the collation panics
+	defer func() {
+		if r := recover(); r != nil {
+			rc = 0
+		}
+	}()
+	fn, ok := cb.get(uintptr(userInfo)).(CollationFunc)
+	if !ok {
+		return 0
+	}
+	switch result := fn(C.GoStringN((*C.char)(a), na), C.GoStringN((*C.char)(b), nb)); {
+	case result < 0:
+		return -1
+	case result > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,57 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Collation_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Capture values returned from queries
+	captured := capture(t, db)
+
+	// Collation which sorts in reverse order
+	assert.NoError(db.CreateCollation("reverse", func(a, b string) int {
+		return strings.Compare(b, a)
+	}))
+	assert.NoError(db.Exec("CREATE TABLE test (a TEXT COLLATE reverse)"))
+	assert.NoError(db.Exec("INSERT INTO test VALUES ('b'), ('c'), ('a')"))
+
+	t.Run("001", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.Exec("SELECT capture(group_concat(a)) FROM (SELECT a FROM test ORDER BY a)"))
+		assert.Equal([]any{"c,b,a"}, *captured)
+	})
+
+	t.Run("002", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.Exec("SELECT capture(group_concat(a)) FROM (SELECT a FROM test ORDER BY a COLLATE binary)"))
+		assert.Equal([]any{"a,b,c"}, *captured)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		// A panic compares as equal
+		assert.NoError(db.CreateCollation("broken", func(a, b string) int {
+			panic("collation")
+		}))
+		*captured = nil
+		assert.NoError(db.Exec("SELECT capture('a' = 'b' COLLATE broken)"))
+		assert.Equal([]any{int64(1)}, *captured)
+	})
+
+	t.Run("004", func(t *testing.T) {
+		assert.NoError(db.CreateCollation("REVERSE", nil))
+		assert.Error(db.Exec("SELECT a FROM test ORDER BY a COLLATE reverse"))
+		assert.Error(db.CreateCollation("", nil))
+	})
+}
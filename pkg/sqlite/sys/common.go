@@ -0,0 +1,351 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+// This file contains types and methods which are shared between the cgo
+// and pure-Go implementations
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// ResultError is returned when a connection reports an error, with the extended
+// result code and message. When the error occurred in an SQL statement,
+// the SQL text and the byte offset of the error within the SQL are set,
+// or the offset is -1 if it is not known.
+//
+// Use errors.Is to match on a primary or extended result code. Unique and
+// primary key constraint violations also match ErrDuplicateEntry, and
+// busy or locked errors match ErrRetry.
+type ResultError struct {
+	Code    SQError // Extended result code
+	Message string  // Error message
+	SQL     string  // SQL text, or empty
+	Offset  int     // Byte offset of the error within the SQL, or -1
+}
+
+// callbacks maps keys, which are passed to sqlite as user data, to Go values
+// which cannot be passed to C directly
+type callbacks struct {
+	sync.RWMutex
+	next uintptr
+	m    map[uintptr]any
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	DefaultSchema = "main"
+	DefaultMemory = ":memory:"
+	DefaultFlags  = SQLITE_OPEN_CREATE | SQLITE_OPEN_READWRITE
+)
+
+var cb = &callbacks{m: make(map[uintptr]any)}
+
+var (
+	// ErrRetry is matched by errors which may succeed when the operation is retried
+	ErrRetry = errors.New("database is busy, retry")
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (v OpenFlags) StringFlag() string {
+	switch v {
+	case SQLITE_OPEN_NONE:
+		return "SQLITE_OPEN_NONE"
+	case SQLITE_OPEN_READONLY:
+		return "SQLITE_OPEN_READONLY"
+	case SQLITE_OPEN_READWRITE:
+		return "SQLITE_OPEN_READWRITE"
+	case SQLITE_OPEN_CREATE:
+		return "SQLITE_OPEN_CREATE"
+	case SQLITE_OPEN_URI:
+		return "SQLITE_OPEN_URI"
+	case SQLITE_OPEN_MEMORY:
+		return "SQLITE_OPEN_MEMORY"
+	case SQLITE_OPEN_NOMUTEX:
+		return "SQLITE_OPEN_NOMUTEX"
+	case SQLITE_OPEN_FULLMUTEX:
+		return "SQLITE_OPEN_FULLMUTEX"
+	case SQLITE_OPEN_SHAREDCACHE:
+		return "SQLITE_OPEN_SHAREDCACHE"
+	case SQLITE_OPEN_PRIVATECACHE:
+		return "SQLITE_OPEN_PRIVATECACHE"
+	default:
+		return "[?? Invalid OpenFlags value]"
+	}
+}
+
+func (v OpenFlags) String() string {
+	if v == SQLITE_OPEN_NONE {
+		return v.StringFlag()
+	}
+	str := ""
+	for f := SQLITE_OPEN_MIN; f <= SQLITE_OPEN_MAX; f <<= 1 {
+		if v&f != 0 {
+			str += "|" + f.StringFlag()
+		}
+	}
+	return strings.TrimPrefix(str, "|")
+}
+
+func (t Type) String() string {
+	switch t {
+	case SQLITE_INTEGER:
+		return "INTEGER"
+	case SQLITE_FLOAT:
+		return "FLOAT"
+	case SQLITE_TEXT:
+		return "TEXT"
+	case SQLITE_BLOB:
+		return "BLOB"
+	case SQLITE_NULL:
+		return "NULL"
+	default:
+		return "[?? Invalid Type value]"
+	}
+}
+
+func (v *Value) String() string {
+	str := "<value"
+	str += fmt.Sprint(" type=", v.Type())
+	switch v.Type() {
+	case SQLITE_NULL:
+		// No value
+	case SQLITE_TEXT:
+		str += fmt.Sprintf(" value=%q", v.Text())
+	case SQLITE_BLOB:
+		str += fmt.Sprintf(" value=0x%X", v.Blob())
+	default:
+		str += fmt.Sprint(" value=", v.Interface())
+	}
+	return str + ">"
+}
+
+func (c *Conn) String() string {
+	str := "<conn"
+	if filename := c.Filename(""); filename != "" {
+		str += fmt.Sprintf(" filename=%q", filename)
+	}
+	if readonly := c.Readonly(""); readonly {
+		str += " readonly"
+	}
+	if autocommit := c.Autocommit(); autocommit {
+		str += " autocommit"
+	}
+	if rowid := c.LastInsertId(); rowid != 0 {
+		str += fmt.Sprint(" last_insert_id=", rowid)
+	}
+	if changes := c.Changes(); changes != 0 {
+		str += fmt.Sprint(" rows_affected=", changes)
+	}
+	return str + ">"
+}
+
+func (s *Statement) String() string {
+	str := "<statement"
+	str += fmt.Sprintf(" sql=%q", s.SQL())
+	if n := s.ColumnCount(); n > 0 {
+		cols := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			cols = append(cols, s.ColumnName(i))
+		}
+		str += fmt.Sprintf(" columns=%q", cols)
+	}
+	if readonly := s.Readonly(); readonly {
+		str += " readonly"
+	}
+	return str + ">"
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CONN METHODS
+
+// PrepareContext compiles the first SQL statement in sql, and returns the
+// statement and any remaining text. When the schema is locked by another
+// connection to the same shared cache, PrepareContext waits until the lock
+// is released or the context is cancelled.
+func (c *Conn) PrepareContext(ctx context.Context, sql string) (*Statement, string, error) {
+	for {
+		st, rest, err := c.Prepare(sql)
+		if !errors.Is(err, SQLITE_LOCKED_SHAREDCACHE) {
+			return st, rest, err
+		} else if err := c.waitForUnlockNotify(ctx); err != nil {
+			return nil, "", err
+		}
+	}
+}
+
+// Exec executes one or more SQL statements, ignoring any rows returned. When
+// the database is locked by another connection to the same shared cache, an
+// SQLITE_LOCKED_SHAREDCACHE error is returned. Use ExecContext to wait until
+// the lock is released.
+func (c *Conn) Exec(sql string) error {
+	return c.exec(sql, c.Prepare, (*Statement).step)
+}
+
+// ExecContext executes one or more SQL statements, ignoring any rows returned.
+// When the database is locked by another connection to the same shared cache,
+// ExecContext waits until the lock is released or the context is cancelled.
+func (c *Conn) ExecContext(ctx context.Context, sql string) error {
+	return c.exec(sql, func(sql string) (*Statement, string, error) {
+		return c.PrepareContext(ctx, sql)
+	}, func(st *Statement) (bool, error) {
+		return st.Step(ctx)
+	})
+}
+
+// exec prepares each statement in turn, and steps it until it has finished
+// executing
+func (c *Conn) exec(sql string, prepare func(string) (*Statement, string, error), step func(*Statement) (bool, error)) error {
+	for sql != "" {
+		st, rest, err := prepare(sql)
+		if err != nil {
+			return err
+		} else if st != nil {
+			if err := st.exec(step); err != nil {
+				return err
+			}
+		}
+		sql = rest
+	}
+
+	// Return success
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STATEMENT METHODS
+
+// Step evaluates the statement, and returns true if a row is available
+// or false when the statement has finished executing. When the database is
+// locked by another connection to the same shared cache, Step waits until
+// the lock is released or the context is cancelled.
+func (s *Statement) Step(ctx context.Context) (bool, error) {
+	for {
+		row, err := s.step()
+		if !errors.Is(err, SQLITE_LOCKED_SHAREDCACHE) {
+			return row, err
+		} else if err := s.Conn().waitForUnlockNotify(ctx); err != nil {
+			return false, err
+		}
+		s.Reset()
+	}
+}
+
+// exec steps a statement until it has finished executing, then finalizes it
+func (s *Statement) exec(step func(*Statement) (bool, error)) error {
+	for {
+		if row, err := step(s); err != nil {
+			s.Finalize()
+			return err
+		} else if !row {
+			return s.Finalize()
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// VALUE METHODS
+
+// IsNull returns true if the value is NULL
+func (v *Value) IsNull() bool {
+	return v.Type() == SQLITE_NULL
+}
+
+// Bool returns the value as a boolean
+func (v *Value) Bool() bool {
+	return v.Int64() != 0
+}
+
+// Interface returns the value as int64, float64, string, []byte or nil
+// depending on the datatype of the value
+func (v *Value) Interface() any {
+	switch v.Type() {
+	case SQLITE_INTEGER:
+		return v.Int64()
+	case SQLITE_FLOAT:
+		return v.Float64()
+	case SQLITE_TEXT:
+		return v.Text()
+	case SQLITE_BLOB:
+		return v.Blob()
+	default:
+		return nil
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// ERROR METHODS
+
+func (e SQError) With(suffix string) error {
+	return fmt.Errorf("%w: %v", e, suffix)
+}
+
+// Primary returns the primary result code from an extended result code
+func (e SQError) Primary() SQError {
+	return e & 0xFF
+}
+
+func (e *ResultError) Error() string {
+	if e.Message == "" {
+		return e.Code.Error()
+	}
+	return fmt.Sprintf("%v: %v", e.Code, e.Message)
+}
+
+func (e *ResultError) Unwrap() error {
+	return e.Code
+}
+
+// Is returns true if the target is the primary or extended result code,
+// or the error is a constraint violation and the target is ErrDuplicateEntry,
+// or the error is busy or locked and the target is ErrRetry
+func (e *ResultError) Is(target error) bool {
+	switch target {
+	case e.Code.Primary():
+		return true
+	case ErrDuplicateEntry:
+		return e.Code == SQLITE_CONSTRAINT_UNIQUE || e.Code == SQLITE_CONSTRAINT_PRIMARYKEY
+	case ErrRetry:
+		return e.Code.Primary() == SQLITE_BUSY || e.Code.Primary() == SQLITE_LOCKED
+	default:
+		return false
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACK METHODS
+
+// add a value and return the key, which is never zero
+func (cb *callbacks) add(v any) uintptr {
+	cb.Lock()
+	defer cb.Unlock()
+	cb.next++
+	cb.m[cb.next] = v
+	return cb.next
+}
+
+// get a value from a key, or nil if the key does not exist
+func (cb *callbacks) get(key uintptr) any {
+	cb.RLock()
+	defer cb.RUnlock()
+	return cb.m[key]
+}
+
+// remove a value with a key
+func (cb *callbacks) remove(key uintptr) {
+	cb.Lock()
+	defer cb.Unlock()
+	delete(cb.m, key)
+}
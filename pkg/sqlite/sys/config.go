@@ -0,0 +1,179 @@
+package sqlite
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+
+static inline int _sqlite3_db_config_int(sqlite3* db, int op, int v, int* out) {
+	return sqlite3_db_config(db, op, v, out);
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	Limit    C.int
+	DbConfig C.int
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+// Ref: https://www.sqlite.org/c3ref/c_limit_attached.html
+const (
+	SQLITE_LIMIT_LENGTH              Limit = C.SQLITE_LIMIT_LENGTH              // Maximum size of any string or blob or table row, in bytes
+	SQLITE_LIMIT_SQL_LENGTH          Limit = C.SQLITE_LIMIT_SQL_LENGTH          // Maximum length of an SQL statement, in bytes
+	SQLITE_LIMIT_COLUMN              Limit = C.SQLITE_LIMIT_COLUMN              // Maximum number of columns in a table definition, result set, index or clause
+	SQLITE_LIMIT_EXPR_DEPTH          Limit = C.SQLITE_LIMIT_EXPR_DEPTH          // Maximum depth of the parse tree on any expression
+	SQLITE_LIMIT_COMPOUND_SELECT     Limit = C.SQLITE_LIMIT_COMPOUND_SELECT     // Maximum number of terms in a compound SELECT statement
+	SQLITE_LIMIT_VDBE_OP             Limit = C.SQLITE_LIMIT_VDBE_OP             // Maximum number of instructions in a virtual machine program
+	SQLITE_LIMIT_FUNCTION_ARG        Limit = C.SQLITE_LIMIT_FUNCTION_ARG        // Maximum number of arguments on a function
+	SQLITE_LIMIT_ATTACHED            Limit = C.SQLITE_LIMIT_ATTACHED            // Maximum number of attached databases
+	SQLITE_LIMIT_LIKE_PATTERN_LENGTH Limit = C.SQLITE_LIMIT_LIKE_PATTERN_LENGTH // Maximum length of the pattern argument to the LIKE or GLOB operators
+	SQLITE_LIMIT_VARIABLE_NUMBER     Limit = C.SQLITE_LIMIT_VARIABLE_NUMBER     // Maximum index number of any parameter in an SQL statement
+	SQLITE_LIMIT_TRIGGER_DEPTH       Limit = C.SQLITE_LIMIT_TRIGGER_DEPTH       // Maximum depth of recursion for triggers
+	SQLITE_LIMIT_WORKER_THREADS      Limit = C.SQLITE_LIMIT_WORKER_THREADS      // Maximum number of auxiliary worker threads that a single prepared statement may start
+	SQLITE_LIMIT_MIN                       = SQLITE_LIMIT_LENGTH
+	SQLITE_LIMIT_MAX                       = SQLITE_LIMIT_WORKER_THREADS
+)
+
+// Ref: https://www.sqlite.org/c3ref/c_dbconfig_defensive.html
+const (
+	SQLITE_DBCONFIG_ENABLE_FKEY           DbConfig = C.SQLITE_DBCONFIG_ENABLE_FKEY           // Enforce foreign key constraints
+	SQLITE_DBCONFIG_ENABLE_TRIGGER        DbConfig = C.SQLITE_DBCONFIG_ENABLE_TRIGGER        // Enable triggers
+	SQLITE_DBCONFIG_ENABLE_FTS3_TOKENIZER DbConfig = C.SQLITE_DBCONFIG_ENABLE_FTS3_TOKENIZER // Enable the two-argument fts3_tokenizer() function
+	SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION DbConfig = C.SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION // Enable sqlite3_load_extension, but not the load_extension() SQL function
+	SQLITE_DBCONFIG_NO_CKPT_ON_CLOSE      DbConfig = C.SQLITE_DBCONFIG_NO_CKPT_ON_CLOSE      // Disable the checkpoint when the last connection to a WAL database is closed
+	SQLITE_DBCONFIG_ENABLE_QPSG           DbConfig = C.SQLITE_DBCONFIG_ENABLE_QPSG           // Enable the query planner stability guarantee
+	SQLITE_DBCONFIG_TRIGGER_EQP           DbConfig = C.SQLITE_DBCONFIG_TRIGGER_EQP           // Include triggers in EXPLAIN QUERY PLAN output
+	SQLITE_DBCONFIG_RESET_DATABASE        DbConfig = C.SQLITE_DBCONFIG_RESET_DATABASE        // Allow VACUUM to reset the database to empty
+	SQLITE_DBCONFIG_DEFENSIVE             DbConfig = C.SQLITE_DBCONFIG_DEFENSIVE             // Disable language features which allow the database file to be corrupted
+	SQLITE_DBCONFIG_WRITABLE_SCHEMA       DbConfig = C.SQLITE_DBCONFIG_WRITABLE_SCHEMA       // Allow the sqlite_schema table to be written
+	SQLITE_DBCONFIG_LEGACY_ALTER_TABLE    DbConfig = C.SQLITE_DBCONFIG_LEGACY_ALTER_TABLE    // Use the legacy behaviour of ALTER TABLE RENAME
+	SQLITE_DBCONFIG_DQS_DML               DbConfig = C.SQLITE_DBCONFIG_DQS_DML               // Allow double-quoted string literals in DML statements
+	SQLITE_DBCONFIG_DQS_DDL               DbConfig = C.SQLITE_DBCONFIG_DQS_DDL               // Allow double-quoted string literals in DDL statements
+	SQLITE_DBCONFIG_ENABLE_VIEW           DbConfig = C.SQLITE_DBCONFIG_ENABLE_VIEW           // Enable views
+	SQLITE_DBCONFIG_LEGACY_FILE_FORMAT    DbConfig = C.SQLITE_DBCONFIG_LEGACY_FILE_FORMAT    // Create new databases in the legacy file format
+	SQLITE_DBCONFIG_TRUSTED_SCHEMA        DbConfig = C.SQLITE_DBCONFIG_TRUSTED_SCHEMA        // Allow SQL functions and virtual tables in the schema without the SQLITE_INNOCUOUS flag
+	SQLITE_DBCONFIG_MIN                            = SQLITE_DBCONFIG_ENABLE_FKEY
+	SQLITE_DBCONFIG_MAX                            = SQLITE_DBCONFIG_TRUSTED_SCHEMA
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (l Limit) String() string {
+	switch l {
+	case SQLITE_LIMIT_LENGTH:
+		return "SQLITE_LIMIT_LENGTH"
+	case SQLITE_LIMIT_SQL_LENGTH:
+		return "SQLITE_LIMIT_SQL_LENGTH"
+	case SQLITE_LIMIT_COLUMN:
+		return "SQLITE_LIMIT_COLUMN"
+	case SQLITE_LIMIT_EXPR_DEPTH:
+		return "SQLITE_LIMIT_EXPR_DEPTH"
+	case SQLITE_LIMIT_COMPOUND_SELECT:
+		return "SQLITE_LIMIT_COMPOUND_SELECT"
+	case SQLITE_LIMIT_VDBE_OP:
+		return "SQLITE_LIMIT_VDBE_OP"
+	case SQLITE_LIMIT_FUNCTION_ARG:
+		return "SQLITE_LIMIT_FUNCTION_ARG"
+	case SQLITE_LIMIT_ATTACHED:
+		return "SQLITE_LIMIT_ATTACHED"
+	case SQLITE_LIMIT_LIKE_PATTERN_LENGTH:
+		return "SQLITE_LIMIT_LIKE_PATTERN_LENGTH"
+	case SQLITE_LIMIT_VARIABLE_NUMBER:
+		return "SQLITE_LIMIT_VARIABLE_NUMBER"
+	case SQLITE_LIMIT_TRIGGER_DEPTH:
+		return "SQLITE_LIMIT_TRIGGER_DEPTH"
+	case SQLITE_LIMIT_WORKER_THREADS:
+		return "SQLITE_LIMIT_WORKER_THREADS"
+	default:
+		return "[?? Invalid Limit value]"
+	}
+}
+
+func (v DbConfig) String() string {
+	switch v {
+	case SQLITE_DBCONFIG_ENABLE_FKEY:
+		return "SQLITE_DBCONFIG_ENABLE_FKEY"
+	case SQLITE_DBCONFIG_ENABLE_TRIGGER:
+		return "SQLITE_DBCONFIG_ENABLE_TRIGGER"
+	case SQLITE_DBCONFIG_ENABLE_FTS3_TOKENIZER:
+		return "SQLITE_DBCONFIG_ENABLE_FTS3_TOKENIZER"
+	case SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION:
+		return "SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION"
+	case SQLITE_DBCONFIG_NO_CKPT_ON_CLOSE:
+		return "SQLITE_DBCONFIG_NO_CKPT_ON_CLOSE"
+	case SQLITE_DBCONFIG_ENABLE_QPSG:
+		return "SQLITE_DBCONFIG_ENABLE_QPSG"
+	case SQLITE_DBCONFIG_TRIGGER_EQP:
+		return "SQLITE_DBCONFIG_TRIGGER_EQP"
+	case SQLITE_DBCONFIG_RESET_DATABASE:
+		return "SQLITE_DBCONFIG_RESET_DATABASE"
+	case SQLITE_DBCONFIG_DEFENSIVE:
+		return "SQLITE_DBCONFIG_DEFENSIVE"
+	case SQLITE_DBCONFIG_WRITABLE_SCHEMA:
+		return "SQLITE_DBCONFIG_WRITABLE_SCHEMA"
+	case SQLITE_DBCONFIG_LEGACY_ALTER_TABLE:
+		return "SQLITE_DBCONFIG_LEGACY_ALTER_TABLE"
+	case SQLITE_DBCONFIG_DQS_DML:
+		return "SQLITE_DBCONFIG_DQS_DML"
+	case SQLITE_DBCONFIG_DQS_DDL:
+		return "SQLITE_DBCONFIG_DQS_DDL"
+	case SQLITE_DBCONFIG_ENABLE_VIEW:
+		return "SQLITE_DBCONFIG_ENABLE_VIEW"
+	case SQLITE_DBCONFIG_LEGACY_FILE_FORMAT:
+		return "SQLITE_DBCONFIG_LEGACY_FILE_FORMAT"
+	case SQLITE_DBCONFIG_TRUSTED_SCHEMA:
+		return "SQLITE_DBCONFIG_TRUSTED_SCHEMA"
+	default:
+		return "[?? Invalid DbConfig value]"
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Limit returns the current value of a run-time limit
+func (c *Conn) Limit(l Limit) int {
+	return int(C.sqlite3_limit((*C.sqlite3)(c), C.int(l), -1))
+}
+
+// SetLimit sets a run-time limit and returns the previous value. Values
+// larger than the compile-time maximum are truncated, and negative values
+// leave the limit unchanged.
+func (c *Conn) SetLimit(l Limit, v int) int {
+	return int(C.sqlite3_limit((*C.sqlite3)(c), C.int(l), C.int(v)))
+}
+
+// Config returns the current value of a boolean database configuration option
+func (c *Conn) Config(op DbConfig) (bool, error) {
+	return c.dbconfig(op, -1)
+}
+
+// SetConfig enables or disables a boolean database configuration option
+func (c *Conn) SetConfig(op DbConfig, v bool) error {
+	_, err := c.dbconfig(op, boolToInt(v))
+	return err
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (c *Conn) dbconfig(op DbConfig, v int) (bool, error) {
+	var out C.int
+	if op < SQLITE_DBCONFIG_MIN || op > SQLITE_DBCONFIG_MAX {
+		return false, SQLITE_MISUSE.With(op.String())
+	}
+	if err := SQError(C._sqlite3_db_config_int((*C.sqlite3)(c), C.int(op), C.int(v), &out)); err != SQLITE_OK {
+		return false, newError((*C.sqlite3)(c), err, "")
+	}
+	return intToBool(int(out)), nil
+}
@@ -0,0 +1,65 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Config_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	t.Run("Limit", func(t *testing.T) {
+		for l := sqlite.SQLITE_LIMIT_MIN; l <= sqlite.SQLITE_LIMIT_MAX; l++ {
+			t.Log(l, "=>", db.Limit(l))
+		}
+		prev := db.Limit(sqlite.SQLITE_LIMIT_ATTACHED)
+		assert.Equal(prev, db.SetLimit(sqlite.SQLITE_LIMIT_ATTACHED, 0))
+		assert.Equal(0, db.Limit(sqlite.SQLITE_LIMIT_ATTACHED))
+		assert.Error(db.Exec("ATTACH DATABASE ':memory:' AS other"))
+		assert.Equal(0, db.SetLimit(sqlite.SQLITE_LIMIT_ATTACHED, prev))
+		assert.NoError(db.Exec("ATTACH DATABASE ':memory:' AS other"))
+		assert.NoError(db.Exec("CREATE TABLE test (a, b)"))
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		for op := sqlite.SQLITE_DBCONFIG_MIN; op <= sqlite.SQLITE_DBCONFIG_MAX; op++ {
+			v, err := db.Config(op)
+			assert.NoError(err)
+			t.Log(op, "=>", v)
+		}
+		assert.NoError(db.SetConfig(sqlite.SQLITE_DBCONFIG_ENABLE_FKEY, true))
+		v, err := db.Config(sqlite.SQLITE_DBCONFIG_ENABLE_FKEY)
+		assert.NoError(err)
+		assert.True(v)
+
+		// Triggers disabled
+		assert.NoError(db.SetConfig(sqlite.SQLITE_DBCONFIG_ENABLE_TRIGGER, false))
+		assert.NoError(db.Exec("CREATE TRIGGER test_trigger AFTER INSERT ON test BEGIN DELETE FROM test; END"))
+		assert.NoError(db.Exec("INSERT INTO test VALUES (1, 2)"))
+		assert.Error(db.Exec("CREATE TABLE test (a, b)"))
+
+		_, err = db.Config(sqlite.DbConfig(0))
+		assert.Error(err)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		status, err := db.Status(false)
+		assert.NoError(err)
+		assert.Greater(status.SchemaUsed, 0)
+		t.Logf("%+v", status)
+
+		global, err := sqlite.GetStatus(false)
+		assert.NoError(err)
+		assert.Greater(global.MemoryUsed, int64(0))
+		assert.GreaterOrEqual(global.MemoryUsedHighwater, global.MemoryUsed)
+		t.Logf("%+v", global)
+	})
+}
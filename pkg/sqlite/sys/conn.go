@@ -1,7 +1,6 @@
 package sqlite
 
 import (
-	"fmt"
 	"unsafe"
 
 	// Modules
@@ -34,9 +33,6 @@ func OpenPath(path string, flags OpenFlags, vfs string) (*Conn, error) {
 	var cVfs, cName *C.char
 	var c *C.sqlite3
 
-	// Enable logging globally
-	initLogging()
-
 	// Check for thread safety
 	if C.sqlite3_threadsafe() == 0 {
 		return nil, ErrInternalAppError.With("sqlite library was not compiled for thread-safe operation")
@@ -70,16 +66,18 @@ func OpenPath(path string, flags OpenFlags, vfs string) (*Conn, error) {
 
 	// Call sqlite3_open_v2
 	if err := SQError(C.sqlite3_open_v2(cName, &c, C.int(flags), cVfs)); err != SQLITE_OK {
+		result := newError(c, err, "")
 		if c != nil {
 			C.sqlite3_close_v2(c)
 		}
-		return nil, err.With(C.GoString(C.sqlite3_errmsg((*C.sqlite3)(c))))
+		return nil, result
 	}
 
 	// Set extended error codes
 	if err := SQError(C.sqlite3_extended_result_codes(c, 1)); err != SQLITE_OK {
+		result := newError(c, err, "")
 		C.sqlite3_close_v2(c)
-		return nil, err.With(C.GoString(C.sqlite3_errmsg((*C.sqlite3)(c))))
+		return nil, result
 	}
 
 	return (*Conn)(c), nil
@@ -102,6 +100,13 @@ func (c *Conn) Close() error {
 		}
 	}*/
 
+	// Remove hooks
+	c.SetPreUpdateHook(nil)
+	c.SetWalHook(nil)
+	if err := c.SetAuthorizer(nil); err != nil {
+		result = multierror.Append(result, err)
+	}
+
 	// Close database connection
 	if err := SQError(C.sqlite3_close_v2((*C.sqlite3)(c))); err != SQLITE_OK {
 		result = multierror.Append(result, err)
@@ -111,29 +116,6 @@ func (c *Conn) Close() error {
 	return result
 }
 
-///////////////////////////////////////////////////////////////////////////////
-// STRINGIFY
-
-func (c *Conn) String() string {
-	str := "<conn"
-	if filename := c.Filename(""); filename != "" {
-		str += fmt.Sprintf(" filename=%q", filename)
-	}
-	if readonly := c.Readonly(""); readonly {
-		str += " readonly"
-	}
-	if autocommit := c.Autocommit(); autocommit {
-		str += " autocommit"
-	}
-	if rowid := c.LastInsertId(); rowid != 0 {
-		str += fmt.Sprint(" last_insert_id=", rowid)
-	}
-	if changes := c.Changes(); changes != 0 {
-		str += fmt.Sprint(" rows_affected=", changes)
-	}
-	return str + ">"
-}
-
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
@@ -184,7 +166,7 @@ func (c *Conn) Readonly(schema string) bool {
 // Set extended result codes
 func (c *Conn) SetExtendedResultCodes(v bool) error {
 	if err := SQError(C.sqlite3_extended_result_codes((*C.sqlite3)(c), C.int(boolToInt(v)))); err != SQLITE_OK {
-		return err
+		return newError((*C.sqlite3)(c), err, "")
 	} else {
 		return nil
 	}
@@ -193,7 +175,7 @@ func (c *Conn) SetExtendedResultCodes(v bool) error {
 // Cache Flush
 func (c *Conn) CacheFlush() error {
 	if err := SQError(C.sqlite3_db_cacheflush((*C.sqlite3)(c))); err != SQLITE_OK {
-		return err
+		return newError((*C.sqlite3)(c), err, "")
 	} else {
 		return nil
 	}
@@ -202,7 +184,7 @@ func (c *Conn) CacheFlush() error {
 // Release Memory
 func (c *Conn) ReleaseMemory() error {
 	if err := SQError(C.sqlite3_db_release_memory((*C.sqlite3)(c))); err != SQLITE_OK {
-		return err
+		return newError((*C.sqlite3)(c), err, "")
 	} else {
 		return nil
 	}
@@ -0,0 +1,206 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Open URL
+func OpenUrl(url string, flags OpenFlags, vfs string) (*Conn, error) {
+	return OpenPath(url, flags|SQLITE_OPEN_URI, vfs)
+}
+
+// Open Path
+func OpenPath(path string, flags OpenFlags, vfs string) (*Conn, error) {
+	var cVfs, cName uintptr
+	var err error
+
+	// Each connection has its own thread-local storage
+	c := &Conn{tls: libc.NewTLS()}
+
+	// Check for thread safety
+	if sqlite3.Xsqlite3_threadsafe(c.tls) == 0 {
+		c.tls.Close()
+		return nil, ErrInternalAppError.With("sqlite library was not compiled for thread-safe operation")
+	}
+
+	// Set memory database if empty string
+	if path == "" || path == DefaultMemory {
+		path = DefaultMemory
+		flags |= SQLITE_OPEN_MEMORY
+	}
+
+	// Set flags, add read/write flag if create flag is set
+	if flags == 0 {
+		flags = DefaultFlags
+	}
+	if flags|SQLITE_OPEN_CREATE > 0 {
+		flags |= SQLITE_OPEN_READWRITE
+	}
+
+	// Remove custom flags, which are not supported by sqlite3_open_v2
+	// but are used by higher level packages to add caching, etc.
+	flags &= (SQLITE_OPEN_MAX << 1) - 1
+
+	// Populate CStrings
+	if vfs != "" {
+		if cVfs, err = libc.CString(vfs); err != nil {
+			c.tls.Close()
+			return nil, err
+		}
+		defer libc.Xfree(c.tls, cVfs)
+	}
+	if cName, err = libc.CString(path); err != nil {
+		c.tls.Close()
+		return nil, err
+	}
+	defer libc.Xfree(c.tls, cName)
+
+	// Call sqlite3_open_v2, with the connection returned on the TLS stack
+	pDb := c.tls.Alloc(8)
+	rc := SQError(sqlite3.Xsqlite3_open_v2(c.tls, cName, pDb, int32(flags), cVfs))
+	c.db = libc.AtomicLoadPUintptr(pDb)
+	c.tls.Free(8)
+	if rc != SQLITE_OK {
+		result := newError(c, rc, "")
+		c.close()
+		return nil, result
+	}
+
+	// Set extended error codes
+	if err := SQError(sqlite3.Xsqlite3_extended_result_codes(c.tls, c.db, 1)); err != SQLITE_OK {
+		result := newError(c, err, "")
+		c.close()
+		return nil, result
+	}
+
+	return c, nil
+}
+
+// Close Connection
+func (c *Conn) Close() error {
+	if err := c.close(); err != SQLITE_OK {
+		return err
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Get Filename
+func (c *Conn) Filename(schema string) string {
+	// Set schema to default if empty string
+	if schema == "" {
+		schema = DefaultSchema
+	}
+
+	// Populate CStrings
+	cSchema, err := libc.CString(schema)
+	if err != nil {
+		return ""
+	}
+	defer libc.Xfree(c.tls, cSchema)
+
+	// Call and return
+	return libc.GoString(sqlite3.Xsqlite3_db_filename(c.tls, c.db, cSchema))
+}
+
+// Get Read-only state. Also returns false if database not found
+func (c *Conn) Readonly(schema string) bool {
+	// Set schema to default if empty string
+	if schema == "" {
+		schema = DefaultSchema
+	}
+
+	// Populate CStrings
+	cSchema, err := libc.CString(schema)
+	if err != nil {
+		return false
+	}
+	defer libc.Xfree(c.tls, cSchema)
+
+	// Call and return
+	r := int(sqlite3.Xsqlite3_db_readonly(c.tls, c.db, cSchema))
+	if r == -1 {
+		return false
+	} else {
+		return intToBool(r)
+	}
+}
+
+// Set extended result codes
+func (c *Conn) SetExtendedResultCodes(v bool) error {
+	if err := SQError(sqlite3.Xsqlite3_extended_result_codes(c.tls, c.db, int32(boolToInt(v)))); err != SQLITE_OK {
+		return newError(c, err, "")
+	} else {
+		return nil
+	}
+}
+
+// Cache Flush
+func (c *Conn) CacheFlush() error {
+	if err := SQError(sqlite3.Xsqlite3_db_cacheflush(c.tls, c.db)); err != SQLITE_OK {
+		return newError(c, err, "")
+	} else {
+		return nil
+	}
+}
+
+// Release Memory
+func (c *Conn) ReleaseMemory() error {
+	if err := SQError(sqlite3.Xsqlite3_db_release_memory(c.tls, c.db)); err != SQLITE_OK {
+		return newError(c, err, "")
+	} else {
+		return nil
+	}
+}
+
+// Return autocommit state
+func (c *Conn) Autocommit() bool {
+	return intToBool(int(sqlite3.Xsqlite3_get_autocommit(c.tls, c.db)))
+}
+
+// Get last insert id
+func (c *Conn) LastInsertId() int64 {
+	return int64(sqlite3.Xsqlite3_last_insert_rowid(c.tls, c.db))
+}
+
+// Set last insert id
+func (c *Conn) SetLastInsertId(v int64) {
+	sqlite3.Xsqlite3_set_last_insert_rowid(c.tls, c.db, sqlite3.Sqlite3_int64(v))
+}
+
+// Get number of changes (rows affected)
+func (c *Conn) Changes() int {
+	return int(sqlite3.Xsqlite3_changes(c.tls, c.db))
+}
+
+// Interrupt all queries for connection, which can be called from any goroutine
+func (c *Conn) Interrupt() {
+	tls := libc.NewTLS()
+	defer tls.Close()
+	sqlite3.Xsqlite3_interrupt(tls, c.db)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// close the database connection and release the thread-local storage
+func (c *Conn) close() SQError {
+	err := SQError(sqlite3.Xsqlite3_close_v2(c.tls, c.db))
+	if err == SQLITE_OK {
+		c.db = 0
+		c.tls.Close()
+	}
+	return err
+}
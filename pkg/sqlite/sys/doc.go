@@ -1,4 +1,7 @@
 /*
 Package sqlite provides bindings for sqlite 3.
+
+When cgo is disabled, the bindings use a pure-Go port of sqlite instead,
+which provides connections, prepared statements, keywords and the version.
 */
 package sqlite
@@ -1,7 +1,5 @@
 package sqlite
 
-import "fmt"
-
 ///////////////////////////////////////////////////////////////////////////////
 // CGO
 
@@ -54,6 +52,83 @@ const (
 	SQLITE_DONE       SQError = C.SQLITE_DONE       /* sqlite3_step() has finished executing */
 )
 
+// Extended result codes
+const (
+	SQLITE_ERROR_MISSING_COLLSEQ   SQError = C.SQLITE_ERROR_MISSING_COLLSEQ
+	SQLITE_ERROR_RETRY             SQError = C.SQLITE_ERROR_RETRY
+	SQLITE_ERROR_SNAPSHOT          SQError = C.SQLITE_ERROR_SNAPSHOT
+	SQLITE_IOERR_READ              SQError = C.SQLITE_IOERR_READ
+	SQLITE_IOERR_SHORT_READ        SQError = C.SQLITE_IOERR_SHORT_READ
+	SQLITE_IOERR_WRITE             SQError = C.SQLITE_IOERR_WRITE
+	SQLITE_IOERR_FSYNC             SQError = C.SQLITE_IOERR_FSYNC
+	SQLITE_IOERR_DIR_FSYNC         SQError = C.SQLITE_IOERR_DIR_FSYNC
+	SQLITE_IOERR_TRUNCATE          SQError = C.SQLITE_IOERR_TRUNCATE
+	SQLITE_IOERR_FSTAT             SQError = C.SQLITE_IOERR_FSTAT
+	SQLITE_IOERR_UNLOCK            SQError = C.SQLITE_IOERR_UNLOCK
+	SQLITE_IOERR_RDLOCK            SQError = C.SQLITE_IOERR_RDLOCK
+	SQLITE_IOERR_DELETE            SQError = C.SQLITE_IOERR_DELETE
+	SQLITE_IOERR_BLOCKED           SQError = C.SQLITE_IOERR_BLOCKED
+	SQLITE_IOERR_NOMEM             SQError = C.SQLITE_IOERR_NOMEM
+	SQLITE_IOERR_ACCESS            SQError = C.SQLITE_IOERR_ACCESS
+	SQLITE_IOERR_CHECKRESERVEDLOCK SQError = C.SQLITE_IOERR_CHECKRESERVEDLOCK
+	SQLITE_IOERR_LOCK              SQError = C.SQLITE_IOERR_LOCK
+	SQLITE_IOERR_CLOSE             SQError = C.SQLITE_IOERR_CLOSE
+	SQLITE_IOERR_DIR_CLOSE         SQError = C.SQLITE_IOERR_DIR_CLOSE
+	SQLITE_IOERR_SHMOPEN           SQError = C.SQLITE_IOERR_SHMOPEN
+	SQLITE_IOERR_SHMSIZE           SQError = C.SQLITE_IOERR_SHMSIZE
+	SQLITE_IOERR_SHMLOCK           SQError = C.SQLITE_IOERR_SHMLOCK
+	SQLITE_IOERR_SHMMAP            SQError = C.SQLITE_IOERR_SHMMAP
+	SQLITE_IOERR_SEEK              SQError = C.SQLITE_IOERR_SEEK
+	SQLITE_IOERR_DELETE_NOENT      SQError = C.SQLITE_IOERR_DELETE_NOENT
+	SQLITE_IOERR_MMAP              SQError = C.SQLITE_IOERR_MMAP
+	SQLITE_IOERR_GETTEMPPATH       SQError = C.SQLITE_IOERR_GETTEMPPATH
+	SQLITE_IOERR_CONVPATH          SQError = C.SQLITE_IOERR_CONVPATH
+	SQLITE_IOERR_VNODE             SQError = C.SQLITE_IOERR_VNODE
+	SQLITE_IOERR_AUTH              SQError = C.SQLITE_IOERR_AUTH
+	SQLITE_IOERR_BEGIN_ATOMIC      SQError = C.SQLITE_IOERR_BEGIN_ATOMIC
+	SQLITE_IOERR_COMMIT_ATOMIC     SQError = C.SQLITE_IOERR_COMMIT_ATOMIC
+	SQLITE_IOERR_ROLLBACK_ATOMIC   SQError = C.SQLITE_IOERR_ROLLBACK_ATOMIC
+	SQLITE_IOERR_DATA              SQError = C.SQLITE_IOERR_DATA
+	SQLITE_IOERR_CORRUPTFS         SQError = C.SQLITE_IOERR_CORRUPTFS
+	SQLITE_LOCKED_SHAREDCACHE      SQError = C.SQLITE_LOCKED_SHAREDCACHE
+	SQLITE_LOCKED_VTAB             SQError = C.SQLITE_LOCKED_VTAB
+	SQLITE_BUSY_RECOVERY           SQError = C.SQLITE_BUSY_RECOVERY
+	SQLITE_BUSY_SNAPSHOT           SQError = C.SQLITE_BUSY_SNAPSHOT
+	SQLITE_BUSY_TIMEOUT            SQError = C.SQLITE_BUSY_TIMEOUT
+	SQLITE_CANTOPEN_NOTEMPDIR      SQError = C.SQLITE_CANTOPEN_NOTEMPDIR
+	SQLITE_CANTOPEN_ISDIR          SQError = C.SQLITE_CANTOPEN_ISDIR
+	SQLITE_CANTOPEN_FULLPATH       SQError = C.SQLITE_CANTOPEN_FULLPATH
+	SQLITE_CANTOPEN_CONVPATH       SQError = C.SQLITE_CANTOPEN_CONVPATH
+	SQLITE_CANTOPEN_DIRTYWAL       SQError = C.SQLITE_CANTOPEN_DIRTYWAL
+	SQLITE_CANTOPEN_SYMLINK        SQError = C.SQLITE_CANTOPEN_SYMLINK
+	SQLITE_CORRUPT_VTAB            SQError = C.SQLITE_CORRUPT_VTAB
+	SQLITE_CORRUPT_SEQUENCE        SQError = C.SQLITE_CORRUPT_SEQUENCE
+	SQLITE_CORRUPT_INDEX           SQError = C.SQLITE_CORRUPT_INDEX
+	SQLITE_READONLY_RECOVERY       SQError = C.SQLITE_READONLY_RECOVERY
+	SQLITE_READONLY_CANTLOCK       SQError = C.SQLITE_READONLY_CANTLOCK
+	SQLITE_READONLY_ROLLBACK       SQError = C.SQLITE_READONLY_ROLLBACK
+	SQLITE_READONLY_DBMOVED        SQError = C.SQLITE_READONLY_DBMOVED
+	SQLITE_READONLY_CANTINIT       SQError = C.SQLITE_READONLY_CANTINIT
+	SQLITE_READONLY_DIRECTORY      SQError = C.SQLITE_READONLY_DIRECTORY
+	SQLITE_ABORT_ROLLBACK          SQError = C.SQLITE_ABORT_ROLLBACK
+	SQLITE_CONSTRAINT_CHECK        SQError = C.SQLITE_CONSTRAINT_CHECK
+	SQLITE_CONSTRAINT_COMMITHOOK   SQError = C.SQLITE_CONSTRAINT_COMMITHOOK
+	SQLITE_CONSTRAINT_FOREIGNKEY   SQError = C.SQLITE_CONSTRAINT_FOREIGNKEY
+	SQLITE_CONSTRAINT_FUNCTION     SQError = C.SQLITE_CONSTRAINT_FUNCTION
+	SQLITE_CONSTRAINT_NOTNULL      SQError = C.SQLITE_CONSTRAINT_NOTNULL
+	SQLITE_CONSTRAINT_PRIMARYKEY   SQError = C.SQLITE_CONSTRAINT_PRIMARYKEY
+	SQLITE_CONSTRAINT_TRIGGER      SQError = C.SQLITE_CONSTRAINT_TRIGGER
+	SQLITE_CONSTRAINT_UNIQUE       SQError = C.SQLITE_CONSTRAINT_UNIQUE
+	SQLITE_CONSTRAINT_VTAB         SQError = C.SQLITE_CONSTRAINT_VTAB
+	SQLITE_CONSTRAINT_ROWID        SQError = C.SQLITE_CONSTRAINT_ROWID
+	SQLITE_CONSTRAINT_PINNED       SQError = C.SQLITE_CONSTRAINT_PINNED
+	SQLITE_CONSTRAINT_DATATYPE     SQError = C.SQLITE_CONSTRAINT_DATATYPE
+	SQLITE_NOTICE_RECOVER_WAL      SQError = C.SQLITE_NOTICE_RECOVER_WAL
+	SQLITE_NOTICE_RECOVER_ROLLBACK SQError = C.SQLITE_NOTICE_RECOVER_ROLLBACK
+	SQLITE_WARNING_AUTOINDEX       SQError = C.SQLITE_WARNING_AUTOINDEX
+	SQLITE_AUTH_USER               SQError = C.SQLITE_AUTH_USER
+)
+
 ///////////////////////////////////////////////////////////////////////////////
 // ERROR IMPLEMENTATION
 
@@ -61,6 +136,23 @@ func (e SQError) Error() string {
 	return C.GoString(C.sqlite3_errstr(C.int(e)))
 }
 
-func (e SQError) With(suffix string) error {
-	return fmt.Errorf("%w: %v", e, suffix)
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// newError returns an error from a connection for a result code, and the SQL
+// text if the error occurred in an SQL statement. The extended result code is
+// used when it corresponds to the result code.
+func newError(db *C.sqlite3, code SQError, sql string) *ResultError {
+	err := &ResultError{Code: code, SQL: sql, Offset: -1}
+	if db == nil {
+		return err
+	}
+	if ext := SQError(C.sqlite3_extended_errcode(db)); ext.Primary() == code.Primary() {
+		err.Code = ext
+	}
+	err.Message = C.GoString(C.sqlite3_errmsg(db))
+	if sql != "" {
+		err.Offset = int(C.sqlite3_error_offset(db))
+	}
+	return err
 }
@@ -0,0 +1,158 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type SQError int32
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	SQLITE_OK         SQError = sqlite3.SQLITE_OK         /* Successful result */
+	SQLITE_ERROR      SQError = sqlite3.SQLITE_ERROR      /* Generic error */
+	SQLITE_INTERNAL   SQError = sqlite3.SQLITE_INTERNAL   /* Internal logic error in SQLite */
+	SQLITE_PERM       SQError = sqlite3.SQLITE_PERM       /* Access permission denied */
+	SQLITE_ABORT      SQError = sqlite3.SQLITE_ABORT      /* Callback routine requested an abort */
+	SQLITE_BUSY       SQError = sqlite3.SQLITE_BUSY       /* The database file is locked */
+	SQLITE_LOCKED     SQError = sqlite3.SQLITE_LOCKED     /* A table in the database is locked */
+	SQLITE_NOMEM      SQError = sqlite3.SQLITE_NOMEM      /* A malloc() failed */
+	SQLITE_READONLY   SQError = sqlite3.SQLITE_READONLY   /* Attempt to write a readonly database */
+	SQLITE_INTERRUPT  SQError = sqlite3.SQLITE_INTERRUPT  /* Operation terminated by sqlite3_interrupt()*/
+	SQLITE_IOERR      SQError = sqlite3.SQLITE_IOERR      /* Some kind of disk I/O error occurred */
+	SQLITE_CORRUPT    SQError = sqlite3.SQLITE_CORRUPT    /* The database disk image is malformed */
+	SQLITE_NOTFOUND   SQError = sqlite3.SQLITE_NOTFOUND   /* Unknown opcode in sqlite3_file_control() */
+	SQLITE_FULL       SQError = sqlite3.SQLITE_FULL       /* Insertion failed because database is full */
+	SQLITE_CANTOPEN   SQError = sqlite3.SQLITE_CANTOPEN   /* Unable to open the database file */
+	SQLITE_PROTOCOL   SQError = sqlite3.SQLITE_PROTOCOL   /* Database lock protocol error */
+	SQLITE_EMPTY      SQError = sqlite3.SQLITE_EMPTY      /* Internal use only */
+	SQLITE_SCHEMA     SQError = sqlite3.SQLITE_SCHEMA     /* The database schema changed */
+	SQLITE_TOOBIG     SQError = sqlite3.SQLITE_TOOBIG     /* String or BLOB exceeds size limit */
+	SQLITE_CONSTRAINT SQError = sqlite3.SQLITE_CONSTRAINT /* Abort due to constraint violation */
+	SQLITE_MISMATCH   SQError = sqlite3.SQLITE_MISMATCH   /* Data type mismatch */
+	SQLITE_MISUSE     SQError = sqlite3.SQLITE_MISUSE     /* Library used incorrectly */
+	SQLITE_NOLFS      SQError = sqlite3.SQLITE_NOLFS      /* Uses OS features not supported on host */
+	SQLITE_AUTH       SQError = sqlite3.SQLITE_AUTH       /* Authorization denied */
+	SQLITE_FORMAT     SQError = sqlite3.SQLITE_FORMAT     /* Not used */
+	SQLITE_RANGE      SQError = sqlite3.SQLITE_RANGE      /* 2nd parameter to sqlite3_bind out of range */
+	SQLITE_NOTADB     SQError = sqlite3.SQLITE_NOTADB     /* File opened that is not a database file */
+	SQLITE_NOTICE     SQError = sqlite3.SQLITE_NOTICE     /* Notifications from sqlite3_log() */
+	SQLITE_WARNING    SQError = sqlite3.SQLITE_WARNING    /* Warnings from sqlite3_log() */
+	SQLITE_ROW        SQError = sqlite3.SQLITE_ROW        /* sqlite3_step() has another row ready */
+	SQLITE_DONE       SQError = sqlite3.SQLITE_DONE       /* sqlite3_step() has finished executing */
+)
+
+// Extended result codes
+const (
+	SQLITE_ERROR_MISSING_COLLSEQ   SQError = sqlite3.SQLITE_ERROR_MISSING_COLLSEQ
+	SQLITE_ERROR_RETRY             SQError = sqlite3.SQLITE_ERROR_RETRY
+	SQLITE_ERROR_SNAPSHOT          SQError = sqlite3.SQLITE_ERROR_SNAPSHOT
+	SQLITE_IOERR_READ              SQError = sqlite3.SQLITE_IOERR_READ
+	SQLITE_IOERR_SHORT_READ        SQError = sqlite3.SQLITE_IOERR_SHORT_READ
+	SQLITE_IOERR_WRITE             SQError = sqlite3.SQLITE_IOERR_WRITE
+	SQLITE_IOERR_FSYNC             SQError = sqlite3.SQLITE_IOERR_FSYNC
+	SQLITE_IOERR_DIR_FSYNC         SQError = sqlite3.SQLITE_IOERR_DIR_FSYNC
+	SQLITE_IOERR_TRUNCATE          SQError = sqlite3.SQLITE_IOERR_TRUNCATE
+	SQLITE_IOERR_FSTAT             SQError = sqlite3.SQLITE_IOERR_FSTAT
+	SQLITE_IOERR_UNLOCK            SQError = sqlite3.SQLITE_IOERR_UNLOCK
+	SQLITE_IOERR_RDLOCK            SQError = sqlite3.SQLITE_IOERR_RDLOCK
+	SQLITE_IOERR_DELETE            SQError = sqlite3.SQLITE_IOERR_DELETE
+	SQLITE_IOERR_BLOCKED           SQError = sqlite3.SQLITE_IOERR_BLOCKED
+	SQLITE_IOERR_NOMEM             SQError = sqlite3.SQLITE_IOERR_NOMEM
+	SQLITE_IOERR_ACCESS            SQError = sqlite3.SQLITE_IOERR_ACCESS
+	SQLITE_IOERR_CHECKRESERVEDLOCK SQError = sqlite3.SQLITE_IOERR_CHECKRESERVEDLOCK
+	SQLITE_IOERR_LOCK              SQError = sqlite3.SQLITE_IOERR_LOCK
+	SQLITE_IOERR_CLOSE             SQError = sqlite3.SQLITE_IOERR_CLOSE
+	SQLITE_IOERR_DIR_CLOSE         SQError = sqlite3.SQLITE_IOERR_DIR_CLOSE
+	SQLITE_IOERR_SHMOPEN           SQError = sqlite3.SQLITE_IOERR_SHMOPEN
+	SQLITE_IOERR_SHMSIZE           SQError = sqlite3.SQLITE_IOERR_SHMSIZE
+	SQLITE_IOERR_SHMLOCK           SQError = sqlite3.SQLITE_IOERR_SHMLOCK
+	SQLITE_IOERR_SHMMAP            SQError = sqlite3.SQLITE_IOERR_SHMMAP
+	SQLITE_IOERR_SEEK              SQError = sqlite3.SQLITE_IOERR_SEEK
+	SQLITE_IOERR_DELETE_NOENT      SQError = sqlite3.SQLITE_IOERR_DELETE_NOENT
+	SQLITE_IOERR_MMAP              SQError = sqlite3.SQLITE_IOERR_MMAP
+	SQLITE_IOERR_GETTEMPPATH       SQError = sqlite3.SQLITE_IOERR_GETTEMPPATH
+	SQLITE_IOERR_CONVPATH          SQError = sqlite3.SQLITE_IOERR_CONVPATH
+	SQLITE_IOERR_VNODE             SQError = sqlite3.SQLITE_IOERR_VNODE
+	SQLITE_IOERR_AUTH              SQError = sqlite3.SQLITE_IOERR_AUTH
+	SQLITE_IOERR_BEGIN_ATOMIC      SQError = sqlite3.SQLITE_IOERR_BEGIN_ATOMIC
+	SQLITE_IOERR_COMMIT_ATOMIC     SQError = sqlite3.SQLITE_IOERR_COMMIT_ATOMIC
+	SQLITE_IOERR_ROLLBACK_ATOMIC   SQError = sqlite3.SQLITE_IOERR_ROLLBACK_ATOMIC
+	SQLITE_IOERR_DATA              SQError = sqlite3.SQLITE_IOERR_DATA
+	SQLITE_IOERR_CORRUPTFS         SQError = sqlite3.SQLITE_IOERR_CORRUPTFS
+	SQLITE_LOCKED_SHAREDCACHE      SQError = sqlite3.SQLITE_LOCKED_SHAREDCACHE
+	SQLITE_LOCKED_VTAB             SQError = sqlite3.SQLITE_LOCKED_VTAB
+	SQLITE_BUSY_RECOVERY           SQError = sqlite3.SQLITE_BUSY_RECOVERY
+	SQLITE_BUSY_SNAPSHOT           SQError = sqlite3.SQLITE_BUSY_SNAPSHOT
+	SQLITE_BUSY_TIMEOUT            SQError = sqlite3.SQLITE_BUSY_TIMEOUT
+	SQLITE_CANTOPEN_NOTEMPDIR      SQError = sqlite3.SQLITE_CANTOPEN_NOTEMPDIR
+	SQLITE_CANTOPEN_ISDIR          SQError = sqlite3.SQLITE_CANTOPEN_ISDIR
+	SQLITE_CANTOPEN_FULLPATH       SQError = sqlite3.SQLITE_CANTOPEN_FULLPATH
+	SQLITE_CANTOPEN_CONVPATH       SQError = sqlite3.SQLITE_CANTOPEN_CONVPATH
+	SQLITE_CANTOPEN_DIRTYWAL       SQError = sqlite3.SQLITE_CANTOPEN_DIRTYWAL
+	SQLITE_CANTOPEN_SYMLINK        SQError = sqlite3.SQLITE_CANTOPEN_SYMLINK
+	SQLITE_CORRUPT_VTAB            SQError = sqlite3.SQLITE_CORRUPT_VTAB
+	SQLITE_CORRUPT_SEQUENCE        SQError = sqlite3.SQLITE_CORRUPT_SEQUENCE
+	SQLITE_CORRUPT_INDEX           SQError = sqlite3.SQLITE_CORRUPT_INDEX
+	SQLITE_READONLY_RECOVERY       SQError = sqlite3.SQLITE_READONLY_RECOVERY
+	SQLITE_READONLY_CANTLOCK       SQError = sqlite3.SQLITE_READONLY_CANTLOCK
+	SQLITE_READONLY_ROLLBACK       SQError = sqlite3.SQLITE_READONLY_ROLLBACK
+	SQLITE_READONLY_DBMOVED        SQError = sqlite3.SQLITE_READONLY_DBMOVED
+	SQLITE_READONLY_CANTINIT       SQError = sqlite3.SQLITE_READONLY_CANTINIT
+	SQLITE_READONLY_DIRECTORY      SQError = sqlite3.SQLITE_READONLY_DIRECTORY
+	SQLITE_ABORT_ROLLBACK          SQError = sqlite3.SQLITE_ABORT_ROLLBACK
+	SQLITE_CONSTRAINT_CHECK        SQError = sqlite3.SQLITE_CONSTRAINT_CHECK
+	SQLITE_CONSTRAINT_COMMITHOOK   SQError = sqlite3.SQLITE_CONSTRAINT_COMMITHOOK
+	SQLITE_CONSTRAINT_FOREIGNKEY   SQError = sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+	SQLITE_CONSTRAINT_FUNCTION     SQError = sqlite3.SQLITE_CONSTRAINT_FUNCTION
+	SQLITE_CONSTRAINT_NOTNULL      SQError = sqlite3.SQLITE_CONSTRAINT_NOTNULL
+	SQLITE_CONSTRAINT_PRIMARYKEY   SQError = sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	SQLITE_CONSTRAINT_TRIGGER      SQError = sqlite3.SQLITE_CONSTRAINT_TRIGGER
+	SQLITE_CONSTRAINT_UNIQUE       SQError = sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	SQLITE_CONSTRAINT_VTAB         SQError = sqlite3.SQLITE_CONSTRAINT_VTAB
+	SQLITE_CONSTRAINT_ROWID        SQError = sqlite3.SQLITE_CONSTRAINT_ROWID
+	SQLITE_CONSTRAINT_PINNED       SQError = sqlite3.SQLITE_CONSTRAINT_PINNED
+	SQLITE_CONSTRAINT_DATATYPE     SQError = sqlite3.SQLITE_CONSTRAINT_DATATYPE
+	SQLITE_NOTICE_RECOVER_WAL      SQError = sqlite3.SQLITE_NOTICE_RECOVER_WAL
+	SQLITE_NOTICE_RECOVER_ROLLBACK SQError = sqlite3.SQLITE_NOTICE_RECOVER_ROLLBACK
+	SQLITE_WARNING_AUTOINDEX       SQError = sqlite3.SQLITE_WARNING_AUTOINDEX
+	SQLITE_AUTH_USER               SQError = sqlite3.SQLITE_AUTH_USER
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// ERROR IMPLEMENTATION
+
+func (e SQError) Error() string {
+	tls := libc.NewTLS()
+	defer tls.Close()
+	return libc.GoString(sqlite3.Xsqlite3_errstr(tls, int32(e)))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// newError returns an error from a connection for a result code, and the SQL
+// text if the error occurred in an SQL statement. The extended result code is
+// used when it corresponds to the result code.
+func newError(c *Conn, code SQError, sql string) *ResultError {
+	err := &ResultError{Code: code, SQL: sql, Offset: -1}
+	if c == nil || c.db == 0 {
+		return err
+	}
+	if ext := SQError(sqlite3.Xsqlite3_extended_errcode(c.tls, c.db)); ext.Primary() == code.Primary() {
+		err.Code = ext
+	}
+	err.Message = libc.GoString(sqlite3.Xsqlite3_errmsg(c.tls, c.db))
+	if sql != "" {
+		err.Offset = int(sqlite3.Xsqlite3_error_offset(c.tls, c.db))
+	}
+	return err
+}
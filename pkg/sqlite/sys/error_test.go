@@ -0,0 +1,92 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+func Test_Error_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+	assert.NoError(db.Exec("CREATE TABLE t (a UNIQUE, b NOT NULL)"))
+	assert.NoError(db.Exec("INSERT INTO t VALUES (1, 1)"))
+
+	t.Run("Unique", func(t *testing.T) {
+		err := db.Exec("INSERT INTO t VALUES (1, 1)")
+		var sqerr *sqlite.ResultError
+		assert.True(errors.As(err, &sqerr))
+		assert.Equal(sqlite.SQLITE_CONSTRAINT_UNIQUE, sqerr.Code)
+		assert.Equal(sqlite.SQLITE_CONSTRAINT, sqerr.Code.Primary())
+		assert.Equal("INSERT INTO t VALUES (1, 1)", sqerr.SQL)
+		assert.Contains(sqerr.Message, "UNIQUE constraint failed")
+		assert.ErrorIs(err, sqlite.SQLITE_CONSTRAINT)
+		assert.ErrorIs(err, sqlite.SQLITE_CONSTRAINT_UNIQUE)
+		assert.ErrorIs(err, ErrDuplicateEntry)
+		assert.NotErrorIs(err, sqlite.ErrRetry)
+	})
+
+	t.Run("NotNull", func(t *testing.T) {
+		err := db.Exec("INSERT INTO t VALUES (2, NULL)")
+		assert.ErrorIs(err, sqlite.SQLITE_CONSTRAINT_NOTNULL)
+		assert.NotErrorIs(err, sqlite.SQLITE_CONSTRAINT_UNIQUE)
+		assert.NotErrorIs(err, ErrDuplicateEntry)
+	})
+
+	t.Run("Offset", func(t *testing.T) {
+		_, _, err := db.Prepare("SELECT a, c FROM t")
+		var sqerr *sqlite.ResultError
+		assert.True(errors.As(err, &sqerr))
+		assert.Equal(sqlite.SQLITE_ERROR, sqerr.Code)
+		assert.Equal("SELECT a, c FROM t", sqerr.SQL)
+		assert.Equal(10, sqerr.Offset)
+		assert.EqualError(err, "SQL logic error: no such column: c")
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		st, _, err := db.Prepare("INSERT INTO t VALUES (?, ?)")
+		assert.NoError(err)
+		defer st.Finalize()
+		assert.NoError(st.Bind(1, 2))
+		_, err = st.Step(context.Background())
+		var sqerr *sqlite.ResultError
+		assert.True(errors.As(err, &sqerr))
+		assert.Equal(sqlite.SQLITE_CONSTRAINT_UNIQUE, sqerr.Code)
+		assert.Equal("INSERT INTO t VALUES (?, ?)", sqerr.SQL)
+	})
+}
+
+func Test_Error_002(t *testing.T) {
+	assert := assert.New(t)
+	url := "file:Test_Error_002?mode=memory&cache=shared"
+	a, err := sqlite.OpenUrl(url, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer a.Close()
+	b, err := sqlite.OpenUrl(url, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer b.Close()
+
+	// Locked errors can be retried
+	assert.NoError(a.Exec("CREATE TABLE t (a); BEGIN; INSERT INTO t VALUES (1)"))
+	err = b.Exec("SELECT * FROM t")
+	assert.ErrorIs(err, sqlite.SQLITE_LOCKED)
+	assert.ErrorIs(err, sqlite.ErrRetry)
+
+	// Waiting for the lock is cancelled by the context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(b.ExecContext(ctx, "SELECT * FROM t"), context.DeadlineExceeded)
+
+	assert.NoError(a.Exec("COMMIT"))
+	assert.NoError(b.Exec("SELECT * FROM t"))
+}
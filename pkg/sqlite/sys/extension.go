@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+
+extern int go_auto_extension(sqlite3* db, char** pzErrMsg);
+
+static int _sqlite3_auto_extension_entry(sqlite3* db, char** pzErrMsg, const sqlite3_api_routines* pApi) {
+	return go_auto_extension(db, pzErrMsg);
+}
+static inline int _sqlite3_auto_extension() {
+	return sqlite3_auto_extension((void (*)(void))(_sqlite3_auto_extension_entry));
+}
+static inline char* _sqlite3_auto_extension_errmsg(const char* str) {
+	return sqlite3_mprintf("%s", str);
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// AutoExtensionFunc is called for each new connection, before the connection
+// is returned from OpenPath or OpenUrl. It can register functions,
+// collations and modules, or load extensions. Returning an error causes the
+// connection to fail to open.
+type AutoExtensionFunc func(*Conn) error
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	autoMu  sync.RWMutex
+	autoExt []AutoExtensionFunc
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// LoadExtension loads an extension from a shared library. If entry is empty,
+// then sqlite determines the entry point from the filename. Loading extensions
+// is enabled for the C interface only, and only for the duration of the call,
+// so the load_extension() SQL function remains disabled. If loading extensions
+// was already enabled with SetConfig, it remains enabled on return.
+func (c *Conn) LoadExtension(path, entry string) error {
+	var cPath, cEntry, cErrMsg *C.char
+
+	// Check parameters
+	if path == "" {
+		return ErrBadParameter.With("LoadExtension")
+	}
+
+	// Populate CStrings
+	cPath = C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	if entry != "" {
+		cEntry = C.CString(entry)
+		defer C.free(unsafe.Pointer(cEntry))
+	}
+
+	// Enable loading extensions through the C API only, and restore the
+	// previous state on return
+	prev, err := c.dbconfig(SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION, -1)
+	if err != nil {
+		return err
+	}
+	if _, err := c.dbconfig(SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION, 1); err != nil {
+		return err
+	}
+	defer c.dbconfig(SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION, boolToInt(prev))
+
+	// Load the extension
+	if err := SQError(C.sqlite3_load_extension((*C.sqlite3)(c), cPath, cEntry, &cErrMsg)); err != SQLITE_OK {
+		result := newError((*C.sqlite3)(c), err, "")
+		if cErrMsg != nil {
+			result.Message = C.GoString(cErrMsg)
+			C.sqlite3_free(unsafe.Pointer(cErrMsg))
+		}
+		return result
+	}
+
+	// Return success
+	return nil
+}
+
+// AutoExtension registers a function which is called for each connection
+// opened after the call, in the order registered. To load a shared library
+// into every connection, use:
+//
+//	AutoExtension(func(c *Conn) error {
+//		return c.LoadExtension(path, "")
+//	})
+func AutoExtension(fn AutoExtensionFunc) error {
+	if fn == nil {
+		return ErrBadParameter.With("AutoExtension")
+	}
+
+	autoMu.Lock()
+	defer autoMu.Unlock()
+
+	// Register the entry point, which has no effect if it is already registered
+	if err := SQError(C._sqlite3_auto_extension()); err != SQLITE_OK {
+		return err
+	}
+	autoExt = append(autoExt, fn)
+
+	// Return success
+	return nil
+}
+
+// ResetAutoExtension removes all functions registered with AutoExtension.
+// Connections which are already open are not affected.
+func ResetAutoExtension() {
+	autoMu.Lock()
+	defer autoMu.Unlock()
+	C.sqlite3_reset_auto_extension()
+	autoExt = nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Set the error message and return a result code from an error
+func autoExtensionError(err error, pzErrMsg **C.char) C.int {
+	if pzErrMsg != nil {
+		cErrMsg := C.CString(err.Error())
+		*pzErrMsg = C._sqlite3_auto_extension_errmsg(cErrMsg)
+		C.free(unsafe.Pointer(cErrMsg))
+	}
+	var code SQError
+	if errors.As(err, &code) && code != SQLITE_OK {
+		return C.int(code)
+	}
+	return C.int(SQLITE_ERROR)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_auto_extension
+func go_auto_extension(db *C.sqlite3, pzErrMsg **C.char) (rc C.int) {
+	defer func() {
+		if r := recover(); r != nil {
+			rc = autoExtensionError(fmt.Errorf("%v", r), pzErrMsg)
+		}
+	}()
+
+	autoMu.RLock()
+	fns := make([]AutoExtensionFunc, len(autoExt))
+	copy(fns, autoExt)
+	autoMu.RUnlock()
+
+	for _, fn := range fns {
+		if err := fn((*Conn)(db)); err != nil {
+			return autoExtensionError(err, pzErrMsg)
+		}
+	}
+
+	// Return success
+	return C.int(SQLITE_OK)
+}
@@ -0,0 +1,90 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"errors"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Extension_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Missing path
+	assert.Error(db.LoadExtension("", ""))
+
+	// Missing shared library
+	err = db.LoadExtension("/nonexistent/extension", "")
+	assert.Error(err)
+	assert.True(errors.Is(err, sqlite.SQLITE_ERROR))
+	var sqerr *sqlite.ResultError
+	assert.True(errors.As(err, &sqerr))
+	assert.Contains(sqerr.Message, "/nonexistent/extension")
+
+	// load_extension() SQL function remains disabled
+	assert.Error(db.Exec("SELECT load_extension('/nonexistent/extension')"))
+
+	// The previous state is restored on return
+	for _, enable := range []bool{true, false} {
+		assert.NoError(db.SetConfig(sqlite.SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION, enable))
+		assert.Error(db.LoadExtension("/nonexistent/extension", ""))
+		enabled, err := db.Config(sqlite.SQLITE_DBCONFIG_ENABLE_LOAD_EXTENSION)
+		assert.NoError(err)
+		assert.Equal(enable, enabled)
+	}
+}
+
+func Test_Extension_002(t *testing.T) {
+	assert := assert.New(t)
+	defer sqlite.ResetAutoExtension()
+
+	// Register a function for every new connection
+	var opened int
+	assert.Error(sqlite.AutoExtension(nil))
+	assert.NoError(sqlite.AutoExtension(func(c *sqlite.Conn) error {
+		opened++
+		return c.CreateScalarFunction("answer", 0, sqlite.SQLITE_DETERMINISTIC, func(ctx *sqlite.Context, args []*sqlite.Value) {
+			ctx.ResultInt64(42)
+		})
+	}))
+
+	for i := 0; i < 2; i++ {
+		db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+		assert.NoError(err)
+		assert.NoError(db.Exec("SELECT answer()"))
+		assert.NoError(db.Close())
+	}
+	assert.Equal(2, opened)
+
+	// An error prevents the connection from opening
+	assert.NoError(sqlite.AutoExtension(func(c *sqlite.Conn) error {
+		return sqlite.SQLITE_PERM.With("denied")
+	}))
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.Nil(db)
+	assert.ErrorIs(err, sqlite.SQLITE_PERM)
+	assert.Contains(err.Error(), "denied")
+
+	// Reset removes all functions
+	sqlite.ResetAutoExtension()
+	db, err = sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	assert.Error(db.Exec("SELECT answer()"))
+	assert.NoError(db.Close())
+	assert.Equal(3, opened)
+
+	// A panic prevents the connection from opening
+	assert.NoError(sqlite.AutoExtension(func(c *sqlite.Conn) error {
+		panic("panic")
+	}))
+	db, err = sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.Nil(db)
+	assert.ErrorContains(err, "panic")
+}
@@ -0,0 +1,313 @@
+package sqlite
+
+import (
+	"fmt"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+extern void go_func_scalar(sqlite3_context* ctx, int n, sqlite3_value** args);
+extern void go_func_step(sqlite3_context* ctx, int n, sqlite3_value** args);
+extern void go_func_final(sqlite3_context* ctx);
+extern void go_func_value(sqlite3_context* ctx);
+extern void go_func_inverse(sqlite3_context* ctx, int n, sqlite3_value** args);
+extern void go_callback_destroy(void* userInfo);
+
+static inline int _sqlite3_create_scalar_function(sqlite3* db, const char* name, int nargs, int flags, uintptr_t userInfo) {
+	return sqlite3_create_function_v2(db, name, nargs, SQLITE_UTF8 | flags, (void* )(userInfo), go_func_scalar, NULL, NULL, go_callback_destroy);
+}
+static inline int _sqlite3_create_aggregate_function(sqlite3* db, const char* name, int nargs, int flags, uintptr_t userInfo) {
+	return sqlite3_create_function_v2(db, name, nargs, SQLITE_UTF8 | flags, (void* )(userInfo), NULL, go_func_step, go_func_final, go_callback_destroy);
+}
+static inline int _sqlite3_create_window_function(sqlite3* db, const char* name, int nargs, int flags, uintptr_t userInfo) {
+	return sqlite3_create_window_function(db, name, nargs, SQLITE_UTF8 | flags, (void* )(userInfo), go_func_step, go_func_final, go_func_value, go_func_inverse, go_callback_destroy);
+}
+static inline int _sqlite3_delete_function(sqlite3* db, const char* name, int nargs) {
+	return sqlite3_create_function_v2(db, name, nargs, SQLITE_UTF8, NULL, NULL, NULL, NULL, NULL);
+}
+static inline uintptr_t _sqlite3_user_data(sqlite3_context* ctx) {
+	return (uintptr_t)(sqlite3_user_data(ctx));
+}
+static inline uintptr_t* _sqlite3_aggregate_context(sqlite3_context* ctx) {
+	return (uintptr_t* )(sqlite3_aggregate_context(ctx, sizeof(uintptr_t)));
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// FunctionFlag modifies the behaviour of a function
+type FunctionFlag C.int
+
+// ScalarFunc is called with the function context and arguments, and
+// should set the result on the context
+type ScalarFunc func(*Context, []*Value)
+
+// Aggregate is an instance of an aggregate function, created for each
+// aggregation. Step is called for each row, and Final is called to set the
+// result on the context
+type Aggregate interface {
+	Step(*Context, []*Value)
+	Final(*Context)
+}
+
+// WindowAggregate is an instance of an aggregate window function. Value is
+// called to set the current result without ending the aggregation, and
+// Inverse removes the oldest row from the window
+type WindowAggregate interface {
+	Aggregate
+	Value(*Context)
+	Inverse(*Context, []*Value)
+}
+
+// AggregateFunc returns a new aggregate instance
+type AggregateFunc func() Aggregate
+
+// WindowFunc returns a new aggregate window instance
+type WindowFunc func() WindowAggregate
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// The function always returns the same result given the same inputs
+	SQLITE_DETERMINISTIC FunctionFlag = C.SQLITE_DETERMINISTIC
+	// The function may only be invoked from top-level SQL
+	SQLITE_DIRECTONLY FunctionFlag = C.SQLITE_DIRECTONLY
+	// The function is unlikely to cause problems even if misused
+	SQLITE_INNOCUOUS FunctionFlag = C.SQLITE_INNOCUOUS
+	// The function may call sqlite3_value_subtype
+	SQLITE_SUBTYPE   FunctionFlag = C.SQLITE_SUBTYPE
+	SQLITE_FUNC_NONE FunctionFlag = 0
+)
+
+const (
+	// Maximum number of arguments to a function
+	maxFunctionArgs = 127
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (f FunctionFlag) String() string {
+	if f == SQLITE_FUNC_NONE {
+		return f.FlagString()
+	}
+	str := ""
+	for _, v := range []FunctionFlag{SQLITE_DETERMINISTIC, SQLITE_DIRECTONLY, SQLITE_SUBTYPE, SQLITE_INNOCUOUS} {
+		if f&v == v {
+			str += "|" + v.FlagString()
+		}
+	}
+	return str[1:]
+}
+
+func (f FunctionFlag) FlagString() string {
+	switch f {
+	case SQLITE_FUNC_NONE:
+		return "SQLITE_FUNC_NONE"
+	case SQLITE_DETERMINISTIC:
+		return "SQLITE_DETERMINISTIC"
+	case SQLITE_DIRECTONLY:
+		return "SQLITE_DIRECTONLY"
+	case SQLITE_SUBTYPE:
+		return "SQLITE_SUBTYPE"
+	case SQLITE_INNOCUOUS:
+		return "SQLITE_INNOCUOUS"
+	default:
+		return "[?? Invalid FunctionFlag value]"
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// CreateScalarFunction registers a scalar function with a name and number of
+// arguments, or -1 for any number of arguments. If fn is nil, then the function
+// is removed.
+func (c *Conn) CreateScalarFunction(name string, nargs int, flags FunctionFlag, fn ScalarFunc) error {
+	if fn == nil {
+		return c.deleteFunction(name, nargs)
+	}
+	return c.createFunction(name, nargs, flags, fn, func(db *C.sqlite3, cName *C.char, key uintptr) C.int {
+		return C._sqlite3_create_scalar_function(db, cName, C.int(nargs), C.int(flags), C.uintptr_t(key))
+	})
+}
+
+// CreateAggregateFunction registers an aggregate function with a name and
+// number of arguments, or -1 for any number of arguments. The function fn is
+// called to create a new aggregate instance for each aggregation. If fn is nil,
+// then the function is removed.
+func (c *Conn) CreateAggregateFunction(name string, nargs int, flags FunctionFlag, fn AggregateFunc) error {
+	if fn == nil {
+		return c.deleteFunction(name, nargs)
+	}
+	return c.createFunction(name, nargs, flags, fn, func(db *C.sqlite3, cName *C.char, key uintptr) C.int {
+		return C._sqlite3_create_aggregate_function(db, cName, C.int(nargs), C.int(flags), C.uintptr_t(key))
+	})
+}
+
+// CreateWindowFunction registers an aggregate window function with a name and
+// number of arguments, or -1 for any number of arguments. The function can
+// also be used as an ordinary aggregate function. The function fn is called to
+// create a new instance for each aggregation. If fn is nil, then the function
+// is removed.
+func (c *Conn) CreateWindowFunction(name string, nargs int, flags FunctionFlag, fn WindowFunc) error {
+	if fn == nil {
+		return c.deleteFunction(name, nargs)
+	}
+	return c.createFunction(name, nargs, flags, fn, func(db *C.sqlite3, cName *C.char, key uintptr) C.int {
+		return C._sqlite3_create_window_function(db, cName, C.int(nargs), C.int(flags), C.uintptr_t(key))
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (c *Conn) createFunction(name string, nargs int, flags FunctionFlag, fn any, create func(*C.sqlite3, *C.char, uintptr) C.int) error {
+	if name == "" || nargs < -1 || nargs > maxFunctionArgs {
+		return ErrBadParameter.With("CreateFunction")
+	}
+	if flags&^(SQLITE_DETERMINISTIC|SQLITE_DIRECTONLY|SQLITE_SUBTYPE|SQLITE_INNOCUOUS) != 0 {
+		return ErrBadParameter.With("CreateFunction: ", flags)
+	}
+
+	var cName *C.char = C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	// The callback is removed by go_callback_destroy when the function is replaced
+	// or removed, the connection is closed, or the function cannot be created
+	key := cb.add(fn)
+	if err := SQError(create((*C.sqlite3)(c), cName, key)); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
+
+func (c *Conn) deleteFunction(name string, nargs int) error {
+	if name == "" || nargs < -1 || nargs > maxFunctionArgs {
+		return ErrBadParameter.With("DeleteFunction")
+	}
+
+	var cName *C.char = C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	if err := SQError(C._sqlite3_delete_function((*C.sqlite3)(c), cName, C.int(nargs))); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
+
+// Return arguments as a slice of values
+func funcArgs(n C.int, args **C.sqlite3_value) []*Value {
+	if n <= 0 || args == nil {
+		return nil
+	}
+	result := make([]*Value, int(n))
+	for i, arg := range unsafe.Slice(args, int(n)) {
+		result[i] = (*Value)(arg)
+	}
+	return result
+}
+
+// Return the aggregate instance for a context, creating a new one if
+// necessary. Returns nil if the instance could not be created.
+func funcAggregate(ctx *C.sqlite3_context) Aggregate {
+	ptr := C._sqlite3_aggregate_context(ctx)
+	if ptr == nil {
+		C.sqlite3_result_error_nomem(ctx)
+		return nil
+	}
+	if *ptr != 0 {
+		if agg, ok := cb.get(uintptr(*ptr)).(Aggregate); ok {
+			return agg
+		}
+		return nil
+	}
+	var agg Aggregate
+	switch fn := cb.get(uintptr(C._sqlite3_user_data(ctx))).(type) {
+	case AggregateFunc:
+		agg = fn()
+	case WindowFunc:
+		agg = fn()
+	}
+	if agg != nil {
+		*ptr = C.uintptr_t(cb.add(agg))
+	}
+	return agg
+}
+
+// Release the aggregate instance for a context
+func funcAggregateRelease(ctx *C.sqlite3_context) {
+	if ptr := C._sqlite3_aggregate_context(ctx); ptr != nil && *ptr != 0 {
+		cb.remove(uintptr(*ptr))
+		*ptr = 0
+	}
+}
+
+// Set an error result if a function panics
+func funcRecover(ctx *C.sqlite3_context) {
+	if r := recover(); r != nil {
+		(*Context)(ctx).ResultError(fmt.Errorf("%v", r))
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_func_scalar
+func go_func_scalar(ctx *C.sqlite3_context, n C.int, args **C.sqlite3_value) {
+	defer funcRecover(ctx)
+	if fn, ok := cb.get(uintptr(C._sqlite3_user_data(ctx))).(ScalarFunc); ok {
+		fn((*Context)(ctx), funcArgs(n, args))
+	}
+}
+
+//export go_func_step
+func go_func_step(ctx *C.sqlite3_context, n C.int, args **C.sqlite3_value) {
+	defer funcRecover(ctx)
+	if agg := funcAggregate(ctx); agg != nil {
+		agg.Step((*Context)(ctx), funcArgs(n, args))
+	}
+}
+
+//export go_func_final
+func go_func_final(ctx *C.sqlite3_context) {
+	defer funcAggregateRelease(ctx)
+	defer funcRecover(ctx)
+	if agg := funcAggregate(ctx); agg != nil {
+		agg.Final((*Context)(ctx))
+	}
+}
+
+//export go_func_value
+func go_func_value(ctx *C.sqlite3_context) {
+	defer funcRecover(ctx)
+	if agg, ok := funcAggregate(ctx).(WindowAggregate); ok {
+		agg.Value((*Context)(ctx))
+	}
+}
+
+//export go_func_inverse
+func go_func_inverse(ctx *C.sqlite3_context, n C.int, args **C.sqlite3_value) {
+	defer funcRecover(ctx)
+	if agg, ok := funcAggregate(ctx).(WindowAggregate); ok {
+		agg.Inverse((*Context)(ctx), funcArgs(n, args))
+	}
+}
@@ -0,0 +1,112 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"strings"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+type sum struct {
+	v int64
+}
+
+func (s *sum) Step(ctx *sqlite.Context, args []*sqlite.Value) {
+	s.v += args[0].Int64()
+}
+
+func (s *sum) Final(ctx *sqlite.Context) {
+	ctx.ResultInt64(s.v)
+}
+
+func (s *sum) Value(ctx *sqlite.Context) {
+	ctx.ResultInt64(s.v)
+}
+
+func (s *sum) Inverse(ctx *sqlite.Context, args []*sqlite.Value) {
+	s.v -= args[0].Int64()
+}
+
+func Test_Func_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Capture values returned from queries
+	captured := capture(t, db)
+
+	t.Run("001", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.CreateScalarFunction("upper2", 1, sqlite.SQLITE_DETERMINISTIC, func(ctx *sqlite.Context, args []*sqlite.Value) {
+			ctx.ResultText(strings.ToUpper(args[0].Text()) + strings.ToUpper(args[0].Text()))
+		}))
+		assert.NoError(db.Exec("SELECT capture(upper2('ab'), upper2(NULL))"))
+		assert.Equal([]any{"ABAB", ""}, *captured)
+	})
+
+	t.Run("002", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.CreateScalarFunction("typed", 1, 0, func(ctx *sqlite.Context, args []*sqlite.Value) {
+			ctx.Result(args[0].Interface())
+		}))
+		assert.NoError(db.Exec("SELECT capture(typed(1), typed(1.5), typed('x'), typed(x'0102'), typed(NULL))"))
+		assert.Equal([]any{int64(1), 1.5, "x", []byte{1, 2}, nil}, *captured)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		assert.NoError(db.CreateScalarFunction("fail", 0, 0, func(ctx *sqlite.Context, args []*sqlite.Value) {
+			panic("fail")
+		}))
+		assert.Error(db.Exec("SELECT fail()"))
+
+		// Remove the function
+		assert.NoError(db.CreateScalarFunction("fail", 0, 0, nil))
+		assert.Error(db.Exec("SELECT fail()"))
+	})
+
+	t.Run("004", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.CreateAggregateFunction("gosum", 1, sqlite.SQLITE_DETERMINISTIC, func() sqlite.Aggregate {
+			return &sum{}
+		}))
+		assert.NoError(db.Exec("CREATE TABLE test (a INTEGER)"))
+		assert.NoError(db.Exec("INSERT INTO test VALUES (1), (2), (3), (4)"))
+		assert.NoError(db.Exec("SELECT capture(gosum(a)) FROM test"))
+		assert.NoError(db.Exec("SELECT capture(gosum(a)) FROM test WHERE a > 10"))
+		assert.Equal([]any{int64(10), int64(0)}, *captured)
+	})
+
+	t.Run("005", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.CreateWindowFunction("gowsum", 1, sqlite.SQLITE_DETERMINISTIC, func() sqlite.WindowAggregate {
+			return &sum{}
+		}))
+		assert.NoError(db.Exec("SELECT capture(gowsum(a) OVER (ORDER BY a ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)) FROM test"))
+		assert.Equal([]any{int64(1), int64(3), int64(5), int64(7)}, *captured)
+	})
+
+	t.Run("006", func(t *testing.T) {
+		assert.Error(db.CreateScalarFunction("", 0, 0, func(ctx *sqlite.Context, args []*sqlite.Value) {}))
+		assert.Error(db.CreateScalarFunction("bad", 200, 0, func(ctx *sqlite.Context, args []*sqlite.Value) {}))
+	})
+}
+
+// capture registers a scalar function "capture" which appends the values of
+// its arguments to the returned slice
+func capture(t *testing.T, db *sqlite.Conn) *[]any {
+	var captured []any
+	if err := db.CreateScalarFunction("capture", -1, 0, func(ctx *sqlite.Context, args []*sqlite.Value) {
+		for _, arg := range args {
+			captured = append(captured, arg.Interface())
+		}
+		ctx.ResultNull()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return &captured
+}
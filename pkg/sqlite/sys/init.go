@@ -16,6 +16,9 @@ import "C"
 // INIT
 
 func init() {
+	// Logging is not available if the callback cannot be registered, but
+	// that should not prevent sqlite from being used
+	_ = initLogging()
 	if err := SQError(C.sqlite3_initialize()); err != SQLITE_OK {
 		panic(err)
 	}
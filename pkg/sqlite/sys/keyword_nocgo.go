@@ -0,0 +1,53 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return number of keywords
+func KeywordCount() int {
+	tls := libc.NewTLS()
+	defer tls.Close()
+	return int(sqlite3.Xsqlite3_keyword_count(tls))
+}
+
+// Return keyword
+func KeywordName(index int) string {
+	tls := libc.NewTLS()
+	defer tls.Close()
+
+	// Allocate the name and length on the TLS stack
+	pStr := tls.Alloc(8)
+	defer tls.Free(8)
+	pLen := tls.Alloc(4)
+	defer tls.Free(4)
+
+	if err := SQError(sqlite3.Xsqlite3_keyword_name(tls, int32(index), pStr, pLen)); err != SQLITE_OK {
+		return ""
+	} else {
+		return string(libc.GoBytes(libc.AtomicLoadPUintptr(pStr), int(libc.AtomicLoadPInt32(pLen))))
+	}
+}
+
+// Lookup keyword
+func KeywordCheck(v string) bool {
+	tls := libc.NewTLS()
+	defer tls.Close()
+
+	// Populate CString
+	cStr, err := libc.CString(v)
+	if err != nil {
+		return false
+	}
+	defer libc.Xfree(tls, cStr)
+
+	// Return check
+	return intToBool(int(sqlite3.Xsqlite3_keyword_check(tls, cStr, int32(len(v)))))
+}
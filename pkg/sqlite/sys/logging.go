@@ -1,7 +1,6 @@
 package sqlite
 
 import (
-	"fmt"
 	"sync"
 	"unsafe"
 )
@@ -25,21 +24,94 @@ static inline int _sqlite3_config_logging(int enable) {
 */
 import "C"
 
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// LogFunc is called with the result code and message for each message
+// logged by sqlite. It may be called from any goroutine, and should not
+// call back into sqlite.
+type LogFunc func(SQError, string)
+
+// LogLevel is the severity of a logged message
+type LogLevel uint
+
 ///////////////////////////////////////////////////////////////////////////////
 // GLOBALS
 
-var logFn sync.Once
+const (
+	LogNotice  LogLevel = iota // Notices, such as recovery of a WAL file
+	LogWarning                 // Warnings, such as creation of an automatic index
+	LogError                   // Errors
+)
+
+var (
+	logMu    sync.RWMutex
+	logFn    LogFunc
+	logLevel LogLevel
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (v LogLevel) String() string {
+	switch v {
+	case LogNotice:
+		return "LogNotice"
+	case LogWarning:
+		return "LogWarning"
+	case LogError:
+		return "LogError"
+	default:
+		return "[?? Invalid LogLevel value]"
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// SetLogger sets the function which receives messages logged by sqlite with
+// a severity of level or above. If fn is nil, then logging is disabled,
+// which is the default.
+func SetLogger(fn LogFunc, level LogLevel) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	logFn = fn
+	logLevel = level
+}
+
+// Level returns the severity of a logged message with this result code
+func (e SQError) Level() LogLevel {
+	switch e & 0xFF {
+	case SQLITE_NOTICE:
+		return LogNotice
+	case SQLITE_WARNING:
+		return LogWarning
+	default:
+		return LogError
+	}
+}
 
 ///////////////////////////////////////////////////////////////////////////////
 // PRIVATE METHODS
 
-func initLogging() {
-	logFn.Do(func() {
-		C._sqlite3_config_logging(1)
-	})
+// Register the log callback, which needs to happen before sqlite is initialized
+func initLogging() error {
+	if err := SQError(C._sqlite3_config_logging(1)); err != SQLITE_OK {
+		return err
+	}
+	return nil
 }
 
 //export go_config_logger
 func go_config_logger(userInfo unsafe.Pointer, code C.int, message *C.char) {
-	fmt.Println(SQError(code), C.GoString(message))
+	// Call the logger outside the lock, so that it can call SetLogger
+	logMu.RLock()
+	fn, level := logFn, logLevel
+	logMu.RUnlock()
+	if fn == nil {
+		return
+	}
+	if err := SQError(code); err.Level() >= level {
+		fn(err, C.GoString(message))
+	}
 }
@@ -0,0 +1,63 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Logging_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+	defer sqlite.SetLogger(nil, sqlite.LogNotice)
+
+	var codes []sqlite.SQError
+	logger := func(code sqlite.SQError, message string) {
+		t.Log(code, message)
+		codes = append(codes, code)
+	}
+	assert.NoError(db.Exec("CREATE TABLE a (x); CREATE TABLE b (y)"))
+
+	t.Run("001", func(t *testing.T) {
+		codes = nil
+		sqlite.SetLogger(logger, sqlite.LogNotice)
+		assert.Error(db.Exec("SELECT * FROM"))
+		assert.NoError(db.Exec("SELECT * FROM a, b WHERE x = y"))
+		if assert.Len(codes, 2) {
+			assert.Equal(sqlite.LogError, codes[0].Level())
+			assert.Equal(sqlite.LogWarning, codes[1].Level())
+		}
+	})
+
+	t.Run("002", func(t *testing.T) {
+		codes = nil
+		sqlite.SetLogger(logger, sqlite.LogError)
+		assert.Error(db.Exec("SELECT * FROM"))
+		assert.NoError(db.Exec("SELECT * FROM a, b WHERE x = y"))
+		assert.Len(codes, 1)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		codes = nil
+		sqlite.SetLogger(nil, sqlite.LogNotice)
+		assert.Error(db.Exec("SELECT * FROM"))
+		assert.Len(codes, 0)
+	})
+
+	t.Run("004", func(t *testing.T) {
+		codes = nil
+		sqlite.SetLogger(func(code sqlite.SQError, message string) {
+			codes = append(codes, code)
+			sqlite.SetLogger(nil, sqlite.LogNotice)
+		}, sqlite.LogNotice)
+		assert.Error(db.Exec("SELECT * FROM"))
+		assert.Error(db.Exec("SELECT * FROM"))
+		assert.Len(codes, 1)
+	})
+}
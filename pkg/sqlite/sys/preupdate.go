@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"unsafe"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+extern void go_preupdate_hook(void* userInfo, sqlite3* db, int op, char* schema, char* table, sqlite3_int64 oldRowId, sqlite3_int64 newRowId);
+static inline uintptr_t _sqlite3_preupdate_hook(sqlite3* db, uintptr_t userInfo) {
+	if (userInfo == 0) {
+		return (uintptr_t)(sqlite3_preupdate_hook(db, NULL, NULL));
+	}
+	return (uintptr_t)(sqlite3_preupdate_hook(db, (void (*)(void*, sqlite3*, int, char const*, char const*, sqlite3_int64, sqlite3_int64))(go_preupdate_hook), (void* )(userInfo)));
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// PreUpdate describes a row which is about to be inserted, updated or deleted
+type PreUpdate struct {
+	Op       SQAction // SQLITE_INSERT, SQLITE_UPDATE or SQLITE_DELETE
+	Schema   string   // Schema name
+	Table    string   // Table name
+	OldRowId int64    // Rowid of the row before an update or delete, or zero
+	NewRowId int64    // Rowid of the row after an insert or update, or zero
+	Depth    int      // Zero for direct changes, or the trigger depth
+	Old      []any    // Column values before an update or delete, or nil
+	New      []any    // Column values after an insert or update, or nil
+}
+
+// PreUpdateHookFunc is called before each row is changed. Column values are
+// int64, float64, string, []byte or nil.
+type PreUpdateHookFunc func(*PreUpdate)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// SetPreUpdateHook sets a function which is called before each row in a
+// rowid table is inserted, updated or deleted. Any previous hook is
+// replaced. If fn is nil, then the hook is removed.
+func (c *Conn) SetPreUpdateHook(fn PreUpdateHookFunc) {
+	var key uintptr
+	if fn != nil {
+		key = cb.add(fn)
+	}
+	if prev := C._sqlite3_preupdate_hook((*C.sqlite3)(c), C.uintptr_t(key)); prev != 0 {
+		cb.remove(uintptr(prev))
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return column values before or after the change
+func preupdateValues(db *C.sqlite3, old bool) []any {
+	n := int(C.sqlite3_preupdate_count(db))
+	result := make([]any, n)
+	for i := 0; i < n; i++ {
+		var v *C.sqlite3_value
+		var err SQError
+		if old {
+			err = SQError(C.sqlite3_preupdate_old(db, C.int(i), &v))
+		} else {
+			err = SQError(C.sqlite3_preupdate_new(db, C.int(i), &v))
+		}
+		if err == SQLITE_OK && v != nil {
+			result[i] = (*Value)(v).Interface()
+		}
+	}
+	return result
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_preupdate_hook
+func go_preupdate_hook(userInfo unsafe.Pointer, db *C.sqlite3, op C.int, schema, table *C.char, oldRowId, newRowId C.sqlite3_int64) {
+	// Ignore panics, as there is no way to report them
+	defer func() {
+		recover()
+	}()
+	fn, ok := cb.get(uintptr(userInfo)).(PreUpdateHookFunc)
+	if !ok {
+		return
+	}
+	change := &PreUpdate{
+		Op:     SQAction(op),
+		Schema: C.GoString(schema),
+		Table:  C.GoString(table),
+		Depth:  int(C.sqlite3_preupdate_depth(db)),
+	}
+	if change.Op == SQLITE_UPDATE || change.Op == SQLITE_DELETE {
+		change.OldRowId = int64(oldRowId)
+		change.Old = preupdateValues(db, true)
+	}
+	if change.Op == SQLITE_UPDATE || change.Op == SQLITE_INSERT {
+		change.NewRowId = int64(newRowId)
+		change.New = preupdateValues(db, false)
+	}
+	fn(change)
+}
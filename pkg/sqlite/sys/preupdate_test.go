@@ -0,0 +1,77 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_PreUpdate_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Record changes
+	var changes []*sqlite.PreUpdate
+	db.SetPreUpdateHook(func(change *sqlite.PreUpdate) {
+		changes = append(changes, change)
+	})
+	assert.NoError(db.Exec("CREATE TABLE test (a INTEGER PRIMARY KEY, b TEXT, c REAL)"))
+
+	t.Run("001", func(t *testing.T) {
+		changes = nil
+		assert.NoError(db.Exec("INSERT INTO test VALUES (1, 'a', 1.5)"))
+		assert.Len(changes, 1)
+		assert.Equal(&sqlite.PreUpdate{
+			Op:       sqlite.SQLITE_INSERT,
+			Schema:   "main",
+			Table:    "test",
+			NewRowId: 1,
+			New:      []any{int64(1), "a", 1.5},
+		}, changes[0])
+	})
+
+	t.Run("002", func(t *testing.T) {
+		changes = nil
+		assert.NoError(db.Exec("UPDATE test SET a = 2, b = NULL WHERE a = 1"))
+		assert.Len(changes, 1)
+		assert.Equal(sqlite.SQLITE_UPDATE, changes[0].Op)
+		assert.Equal(int64(1), changes[0].OldRowId)
+		assert.Equal(int64(2), changes[0].NewRowId)
+		assert.Equal([]any{int64(1), "a", 1.5}, changes[0].Old)
+		assert.Equal([]any{int64(2), nil, 1.5}, changes[0].New)
+	})
+
+	t.Run("003", func(t *testing.T) {
+		changes = nil
+		assert.NoError(db.Exec("DELETE FROM test"))
+		assert.Len(changes, 1)
+		assert.Equal(sqlite.SQLITE_DELETE, changes[0].Op)
+		assert.Equal(int64(2), changes[0].OldRowId)
+		assert.Equal(int64(0), changes[0].NewRowId)
+		assert.Equal([]any{int64(2), nil, 1.5}, changes[0].Old)
+		assert.Nil(changes[0].New)
+	})
+
+	t.Run("004", func(t *testing.T) {
+		changes = nil
+		db.SetPreUpdateHook(nil)
+		assert.NoError(db.Exec("INSERT INTO test VALUES (1, 'a', 1.5)"))
+		assert.Len(changes, 0)
+	})
+
+	t.Run("005", func(t *testing.T) {
+		// A panic in the hook does not affect the change
+		db.SetPreUpdateHook(func(*sqlite.PreUpdate) {
+			panic("preupdate")
+		})
+		defer db.SetPreUpdateHook(nil)
+		assert.NoError(db.Exec("INSERT INTO test VALUES (2, 'b', 2.5)"))
+		assert.Equal(2, count(t, db, "SELECT count(*) FROM test"))
+	})
+}
@@ -0,0 +1,327 @@
+package sqlite
+
+import (
+	"strings"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Schema is a database attached to a connection
+type Schema struct {
+	Name     string // Schema name, which is "main" for the main database
+	Filename string // Filename, or empty for in-memory and temporary databases
+	Readonly bool   // True if the database is read-only
+}
+
+// Table is a table or view in a schema
+type Table struct {
+	Schema       string // Schema name
+	Name         string // Table name
+	Type         string // One of "table", "view", "virtual" or "shadow"
+	Columns      int    // Number of columns
+	WithoutRowid bool   // True if the table is a WITHOUT ROWID table
+	Strict       bool   // True if the table is a STRICT table
+}
+
+// Column is a column in a table or view
+type Column struct {
+	Name          string // Column name
+	DeclType      string // Declared type, or empty
+	Collation     string // Collating sequence
+	NotNull       bool   // True if the column has a NOT NULL constraint
+	Default       any    // Default value expression, or nil
+	PrimaryKey    int    // Position in the primary key starting at one, or zero
+	AutoIncrement bool   // True if the column is AUTOINCREMENT
+	Hidden        bool   // True if the column is hidden or generated
+}
+
+// Index is an index on a table
+type Index struct {
+	Schema  string   // Schema name
+	Table   string   // Table name
+	Name    string   // Index name
+	Unique  bool     // True if the index is unique
+	Origin  string   // "c" for CREATE INDEX, "u" for UNIQUE or "pk" for PRIMARY KEY
+	Partial bool     // True if the index is a partial index
+	Columns []string // Indexed columns, which are empty for expressions
+}
+
+// Trigger is a trigger on a table or view
+type Trigger struct {
+	Schema string // Schema name
+	Table  string // Table name
+	Name   string // Trigger name
+	SQL    string // Statement which created the trigger
+}
+
+// ForeignKey is a foreign key constraint on a table
+type ForeignKey struct {
+	Table    string   // Referenced table
+	From     []string // Columns in the table
+	To       []string // Columns in the referenced table, which are empty for the primary key
+	OnUpdate string   // Action on update of the referenced key
+	OnDelete string   // Action on delete of the referenced key
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	// Collating sequence for columns without a COLLATE clause
+	defaultCollation = "BINARY"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Schemas returns the main, temporary and attached databases
+func (c *Conn) Schemas() ([]Schema, error) {
+	var result []Schema
+	if err := c.query("SELECT name, file FROM pragma_database_list ORDER BY seq", nil, func(st *Statement) error {
+		result = append(result, Schema{
+			Name:     st.Column(0).Text(),
+			Filename: st.Column(1).Text(),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Readonly = c.Readonly(result[i].Name)
+	}
+	return result, nil
+}
+
+// Tables returns the tables in a schema, excluding views and internal
+// tables. If the schema is empty, the main schema is used.
+func (c *Conn) Tables(schema string) ([]Table, error) {
+	return c.tables(schema, "type != 'view'")
+}
+
+// Views returns the views in a schema. If the schema is empty, the main
+// schema is used.
+func (c *Conn) Views(schema string) ([]Table, error) {
+	return c.tables(schema, "type = 'view'")
+}
+
+// Columns returns the columns of a table or view, in the order they are
+// declared. If the schema is empty, the main schema is used.
+func (c *Conn) Columns(schema, table string) ([]Column, error) {
+	var result []Column
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	if err := c.query("SELECT name, type, \"notnull\", dflt_value, pk, hidden FROM pragma_table_xinfo(?, ?) ORDER BY cid", []any{table, schema}, func(st *Statement) error {
+		result = append(result, Column{
+			Name:       st.Column(0).Text(),
+			DeclType:   st.Column(1).Text(),
+			NotNull:    st.Column(2).Bool(),
+			Default:    st.Column(3).Interface(),
+			PrimaryKey: int(st.Column(4).Int64()),
+			Hidden:     st.Column(5).Int64() != 0,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, ErrNotFound.Withf("Columns: %q", table)
+	}
+
+	// Set collation and autoincrement from the column metadata
+	for i := range result {
+		if err := c.columnMetadata(schema, table, &result[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return success
+	return result, nil
+}
+
+// Indexes returns the indexes on a table, including those created
+// automatically for UNIQUE and PRIMARY KEY constraints. If the schema
+// is empty, the main schema is used.
+func (c *Conn) Indexes(schema, table string) ([]Index, error) {
+	var result []Index
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	if err := c.query("SELECT name, \"unique\", origin, partial FROM pragma_index_list(?, ?) ORDER BY name", []any{table, schema}, func(st *Statement) error {
+		result = append(result, Index{
+			Schema:  schema,
+			Table:   table,
+			Name:    st.Column(0).Text(),
+			Unique:  st.Column(1).Bool(),
+			Origin:  st.Column(2).Text(),
+			Partial: st.Column(3).Bool(),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Set indexed columns
+	for i := range result {
+		if err := c.query("SELECT name FROM pragma_index_info(?, ?) ORDER BY seqno", []any{result[i].Name, schema}, func(st *Statement) error {
+			if !st.Column(0).IsNull() {
+				result[i].Columns = append(result[i].Columns, st.Column(0).Text())
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return success
+	return result, nil
+}
+
+// Triggers returns the triggers on a table or view, or all triggers in the
+// schema if the table is empty. If the schema is empty, the main schema is used.
+func (c *Conn) Triggers(schema, table string) ([]Trigger, error) {
+	var result []Trigger
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	sql := "SELECT tbl_name, name, sql FROM " + quoteIdentifier(schema) + ".sqlite_schema WHERE type = 'trigger' AND (?1 = '' OR tbl_name = ?1 COLLATE NOCASE) ORDER BY name"
+	if err := c.query(sql, []any{table}, func(st *Statement) error {
+		result = append(result, Trigger{
+			Schema: schema,
+			Table:  st.Column(0).Text(),
+			Name:   st.Column(1).Text(),
+			SQL:    st.Column(2).Text(),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ForeignKeys returns the foreign key constraints on a table. If the schema
+// is empty, the main schema is used.
+func (c *Conn) ForeignKeys(schema, table string) ([]ForeignKey, error) {
+	var result []ForeignKey
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	id := int64(-1)
+	if err := c.query("SELECT id, \"table\", \"from\", \"to\", on_update, on_delete FROM pragma_foreign_key_list(?, ?) ORDER BY id, seq", []any{table, schema}, func(st *Statement) error {
+		if st.Column(0).Int64() != id {
+			id = st.Column(0).Int64()
+			result = append(result, ForeignKey{
+				Table:    st.Column(1).Text(),
+				OnUpdate: st.Column(4).Text(),
+				OnDelete: st.Column(5).Text(),
+			})
+		}
+		fk := &result[len(result)-1]
+		fk.From = append(fk.From, st.Column(2).Text())
+		if !st.Column(3).IsNull() {
+			fk.To = append(fk.To, st.Column(3).Text())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// tables returns tables in a schema which match an expression, excluding
+// internal tables
+func (c *Conn) tables(schema, expr string) ([]Table, error) {
+	var result []Table
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	sql := "SELECT name, type, ncol, wr, strict FROM pragma_table_list WHERE schema = ? AND name NOT LIKE 'sqlite\\_%' ESCAPE '\\' AND " + expr + " ORDER BY name"
+	if err := c.query(sql, []any{schema}, func(st *Statement) error {
+		result = append(result, Table{
+			Schema:       schema,
+			Name:         st.Column(0).Text(),
+			Type:         st.Column(1).Text(),
+			Columns:      int(st.Column(2).Int64()),
+			WithoutRowid: st.Column(3).Bool(),
+			Strict:       st.Column(4).Bool(),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// columnMetadata sets the collation and autoincrement for a column. Columns
+// of views and hidden columns of virtual tables have the default collation.
+func (c *Conn) columnMetadata(schema, table string, col *Column) error {
+	var cSchema, cTable, cColumn, cType, cCollation *C.char
+	var notnull, pk, autoinc C.int
+
+	// Populate CStrings
+	cSchema = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+	cTable = C.CString(table)
+	defer C.free(unsafe.Pointer(cTable))
+	cColumn = C.CString(col.Name)
+	defer C.free(unsafe.Pointer(cColumn))
+
+	// Call sqlite3_table_column_metadata
+	if err := SQError(C.sqlite3_table_column_metadata((*C.sqlite3)(c), cSchema, cTable, cColumn, &cType, &cCollation, &notnull, &pk, &autoinc)); err != SQLITE_OK {
+		if err == SQLITE_ERROR {
+			col.Collation = defaultCollation
+			return nil
+		}
+		return newError((*C.sqlite3)(c), err, "")
+	}
+	col.Collation = C.GoString(cCollation)
+	col.AutoIncrement = intToBool(int(autoinc))
+
+	// Return success
+	return nil
+}
+
+// query prepares a single statement, binds arguments and calls a function
+// for each row
+func (c *Conn) query(sql string, args []any, fn func(*Statement) error) error {
+	st, rest, err := c.Prepare(sql)
+	if err != nil {
+		return err
+	} else if st == nil || strings.TrimSpace(rest) != "" {
+		if st != nil {
+			st.Finalize()
+		}
+		return ErrBadParameter.Withf("query: %q", sql)
+	}
+	defer st.Finalize()
+	if err := st.Bind(args...); err != nil {
+		return err
+	}
+	for {
+		if row, err := st.step(); err != nil {
+			return err
+		} else if !row {
+			return nil
+		} else if err := fn(st); err != nil {
+			return err
+		}
+	}
+}
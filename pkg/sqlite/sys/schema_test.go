@@ -0,0 +1,125 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"errors"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+func Test_Schema_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+	assert.NoError(db.Exec(`
+		CREATE TABLE a (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL COLLATE NOCASE UNIQUE, score REAL DEFAULT 0);
+		CREATE TABLE b (a_id INTEGER, a_name TEXT, k ANY, PRIMARY KEY (a_id, k), FOREIGN KEY (a_id, a_name) REFERENCES a (id, name) ON DELETE CASCADE) STRICT, WITHOUT ROWID;
+		CREATE INDEX b_name ON b (a_name) WHERE a_name IS NOT NULL;
+		CREATE VIEW v AS SELECT id, name FROM a;
+		CREATE TRIGGER a_ai AFTER INSERT ON a BEGIN SELECT 1; END;
+		CREATE TRIGGER v_ii INSTEAD OF INSERT ON v BEGIN SELECT 1; END;
+		ATTACH ':memory:' AS other;
+		CREATE TABLE other.c (x);
+	`))
+
+	t.Run("Schemas", func(t *testing.T) {
+		schemas, err := db.Schemas()
+		assert.NoError(err)
+		names := []string{}
+		for _, schema := range schemas {
+			names = append(names, schema.Name)
+		}
+		assert.Equal([]string{"main", "other"}, names)
+	})
+
+	t.Run("Tables", func(t *testing.T) {
+		tables, err := db.Tables("")
+		assert.NoError(err)
+		assert.Equal([]sqlite.Table{
+			{Schema: "main", Name: "a", Type: "table", Columns: 3},
+			{Schema: "main", Name: "b", Type: "table", Columns: 3, WithoutRowid: true, Strict: true},
+		}, tables)
+
+		tables, err = db.Tables("other")
+		assert.NoError(err)
+		assert.Len(tables, 1)
+		assert.Equal("c", tables[0].Name)
+
+		views, err := db.Views("main")
+		assert.NoError(err)
+		assert.Equal([]sqlite.Table{{Schema: "main", Name: "v", Type: "view", Columns: 2}}, views)
+	})
+
+	t.Run("Columns", func(t *testing.T) {
+		cols, err := db.Columns("", "a")
+		assert.NoError(err)
+		assert.Equal([]sqlite.Column{
+			{Name: "id", DeclType: "INTEGER", Collation: "BINARY", PrimaryKey: 1, AutoIncrement: true},
+			{Name: "name", DeclType: "TEXT", Collation: "NOCASE", NotNull: true},
+			{Name: "score", DeclType: "REAL", Collation: "BINARY", Default: "0"},
+		}, cols)
+
+		cols, err = db.Columns("", "b")
+		assert.NoError(err)
+		assert.Len(cols, 3)
+		assert.Equal(1, cols[0].PrimaryKey)
+		assert.Equal(2, cols[2].PrimaryKey)
+
+		cols, err = db.Columns("", "v")
+		assert.NoError(err)
+		assert.Len(cols, 2)
+		assert.Equal("name", cols[1].Name)
+
+		_, err = db.Columns("", "missing")
+		assert.True(errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Indexes", func(t *testing.T) {
+		indexes, err := db.Indexes("", "a")
+		assert.NoError(err)
+		assert.Len(indexes, 1)
+		assert.True(indexes[0].Unique)
+		assert.Equal("u", indexes[0].Origin)
+		assert.Equal([]string{"name"}, indexes[0].Columns)
+
+		indexes, err = db.Indexes("", "b")
+		assert.NoError(err)
+		assert.Len(indexes, 2)
+		assert.Equal("b_name", indexes[0].Name)
+		assert.True(indexes[0].Partial)
+		assert.Equal([]string{"a_name"}, indexes[0].Columns)
+		assert.Equal("pk", indexes[1].Origin)
+	})
+
+	t.Run("Triggers", func(t *testing.T) {
+		triggers, err := db.Triggers("", "")
+		assert.NoError(err)
+		assert.Len(triggers, 2)
+
+		triggers, err = db.Triggers("", "v")
+		assert.NoError(err)
+		assert.Len(triggers, 1)
+		assert.Equal("v_ii", triggers[0].Name)
+		assert.Contains(triggers[0].SQL, "INSTEAD OF INSERT")
+	})
+
+	t.Run("ForeignKeys", func(t *testing.T) {
+		fks, err := db.ForeignKeys("", "b")
+		assert.NoError(err)
+		assert.Equal([]sqlite.ForeignKey{
+			{Table: "a", From: []string{"a_id", "a_name"}, To: []string{"id", "name"}, OnUpdate: "NO ACTION", OnDelete: "CASCADE"},
+		}, fks)
+
+		fks, err = db.ForeignKeys("", "a")
+		assert.NoError(err)
+		assert.Empty(fks)
+	})
+}
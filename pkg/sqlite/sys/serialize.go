@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"math"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <string.h>
+
+static inline unsigned char* _sqlite3_deserialize_buf(void* data, sqlite3_int64 n) {
+	unsigned char* buf = sqlite3_malloc64(n);
+	if (buf != NULL) {
+		memcpy(buf, data, n);
+	}
+	return buf;
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Serialize returns the database image for a schema, which is the same as the
+// content of the database file on disk. If the schema is empty, the main
+// schema is used.
+func (c *Conn) Serialize(schema string) ([]byte, error) {
+	var cSchema *C.char
+	var size C.sqlite3_int64
+
+	// Set schema to default if empty string
+	if schema == "" {
+		schema = DefaultSchema
+	}
+
+	// Populate CStrings
+	cSchema = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+
+	// Serialize into memory obtained from sqlite, and copy
+	data := C.sqlite3_serialize((*C.sqlite3)(c), cSchema, &size, 0)
+	if data == nil {
+		switch {
+		case size < 0:
+			return nil, ErrNotFound.Withf("Serialize: %q", schema)
+		case size == 0:
+			return []byte{}, nil
+		default:
+			return nil, SQLITE_NOMEM
+		}
+	}
+	defer C.sqlite3_free(unsafe.Pointer(data))
+
+	// Check the image fits into a slice, as C.GoBytes would truncate images
+	// of 2GiB or more
+	if int64(size) > math.MaxInt {
+		return nil, SQLITE_TOOBIG
+	}
+
+	// Return success
+	result := make([]byte, int(size))
+	copy(result, unsafe.Slice((*byte)(unsafe.Pointer(data)), int(size)))
+	return result, nil
+}
+
+// Deserialize replaces the database for a schema with a database image, which
+// is copied. The database is then held in memory, and is read-only if readonly
+// is true, or else it can grow as rows are added. If the schema is empty, the
+// main schema is used.
+func (c *Conn) Deserialize(schema string, data []byte, readonly bool) error {
+	var cSchema *C.char
+
+	// Set schema to default if empty string
+	if schema == "" {
+		schema = DefaultSchema
+	}
+
+	// Populate CStrings
+	cSchema = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+
+	// Copy the image into memory obtained from sqlite, which takes ownership
+	var buf *C.uchar
+	if len(data) > 0 {
+		if buf = C._sqlite3_deserialize_buf(unsafe.Pointer(&data[0]), C.sqlite3_int64(len(data))); buf == nil {
+			return SQLITE_NOMEM
+		}
+	}
+
+	// Set flags
+	flags := C.SQLITE_DESERIALIZE_FREEONCLOSE
+	if readonly {
+		flags |= C.SQLITE_DESERIALIZE_READONLY
+	} else {
+		flags |= C.SQLITE_DESERIALIZE_RESIZEABLE
+	}
+
+	// Call sqlite3_deserialize, which frees the buffer on error
+	size := C.sqlite3_int64(len(data))
+	if err := SQError(C.sqlite3_deserialize((*C.sqlite3)(c), cSchema, buf, size, size, C.uint(flags))); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
@@ -0,0 +1,68 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+)
+
+func Test_Serialize_001(t *testing.T) {
+	assert := assert.New(t)
+	src, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer src.Close()
+	assert.NoError(src.Exec("CREATE TABLE t (a); INSERT INTO t VALUES (1), (2)"))
+
+	// Serialize the database image
+	data, err := src.Serialize("")
+	assert.NoError(err)
+	assert.Equal("SQLite format 3\x00", string(data[:16]))
+
+	// Missing schema
+	_, err = src.Serialize("other")
+	assert.True(errors.Is(err, ErrNotFound))
+
+	// Deserialize into a new connection
+	dest, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer dest.Close()
+	assert.NoError(dest.Deserialize("", data, false))
+	assert.NoError(dest.Exec("INSERT INTO t VALUES (3)"))
+	assert.Equal(3, count(t, dest, "SELECT count(*) FROM t"))
+
+	// The source is not modified
+	assert.Equal(2, count(t, src, "SELECT count(*) FROM t"))
+
+	// Read-only image in an attached schema
+	assert.NoError(dest.Exec("ATTACH ':memory:' AS other"))
+	assert.NoError(dest.Deserialize("other", data, true))
+	assert.Equal(2, count(t, dest, "SELECT count(*) FROM other.t"))
+	assert.ErrorIs(dest.Exec("INSERT INTO other.t VALUES (3)"), sqlite.SQLITE_READONLY)
+
+	// Invalid image
+	assert.NoError(dest.Deserialize("other", []byte("not a database"), true))
+	assert.ErrorIs(dest.Exec("SELECT * FROM other.t"), sqlite.SQLITE_NOTADB)
+}
+
+func count(t *testing.T, db *sqlite.Conn, sql string) int {
+	st, _, err := db.Prepare(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Finalize()
+	if row, err := st.Step(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if !row {
+		t.Fatal("no rows")
+	}
+	return int(st.Column(0).Int64())
+}
@@ -0,0 +1,386 @@
+package sqlite
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+extern int go_changeset_filter(void* userInfo, char* table);
+extern int go_changeset_conflict(void* userInfo, int conflict, sqlite3_changeset_iter* iter);
+
+static inline int _sqlite3changeset_apply(sqlite3* db, int n, void* data, int filter, uintptr_t userInfo) {
+	return sqlite3changeset_apply(db, n, data, filter ? (int (*)(void*, const char*))(go_changeset_filter) : NULL, go_changeset_conflict, (void* )(userInfo));
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Session records changes to attached tables in a database, which can be
+// output as a changeset or patchset
+type Session struct {
+	db *C.sqlite3
+	s  *C.sqlite3_session
+}
+
+// ChangesetIter is an iterator over the changes in a changeset
+type ChangesetIter C.sqlite3_changeset_iter
+
+// ConflictType is the reason a change could not be applied
+type ConflictType C.int
+
+// ConflictAction is returned from a conflict handler to indicate how to
+// resolve a conflict
+type ConflictAction C.int
+
+// ConflictFunc is called when a change cannot be applied, with the type of
+// conflict and the change. It returns the action to take.
+type ConflictFunc func(ConflictType, *ChangesetIter) ConflictAction
+
+// FilterFunc is called with each table name in a changeset, and returns
+// false if changes to the table should be ignored
+type FilterFunc func(table string) bool
+
+type changesetApply struct {
+	filter   FilterFunc
+	conflict ConflictFunc
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	SQLITE_CHANGESET_DATA        ConflictType = C.SQLITE_CHANGESET_DATA
+	SQLITE_CHANGESET_NOTFOUND    ConflictType = C.SQLITE_CHANGESET_NOTFOUND
+	SQLITE_CHANGESET_CONFLICT    ConflictType = C.SQLITE_CHANGESET_CONFLICT
+	SQLITE_CHANGESET_CONSTRAINT  ConflictType = C.SQLITE_CHANGESET_CONSTRAINT
+	SQLITE_CHANGESET_FOREIGN_KEY ConflictType = C.SQLITE_CHANGESET_FOREIGN_KEY
+)
+
+const (
+	SQLITE_CHANGESET_OMIT    ConflictAction = C.SQLITE_CHANGESET_OMIT
+	SQLITE_CHANGESET_REPLACE ConflictAction = C.SQLITE_CHANGESET_REPLACE
+	SQLITE_CHANGESET_ABORT   ConflictAction = C.SQLITE_CHANGESET_ABORT
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// CreateSession creates a new session for a database schema, which records
+// changes once tables are attached. The session should be closed before
+// the connection is closed.
+func (c *Conn) CreateSession(schema string) (*Session, error) {
+	var s *C.sqlite3_session
+
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	var cSchema *C.char = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+
+	if err := SQError(C.sqlite3session_create((*C.sqlite3)(c), cSchema, &s)); err != SQLITE_OK {
+		return nil, newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return &Session{db: (*C.sqlite3)(c), s: s}, nil
+}
+
+// Close the session
+func (s *Session) Close() error {
+	if s.s == nil {
+		return nil
+	}
+	C.sqlite3session_delete(s.s)
+	s.s = nil
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (s *Session) String() string {
+	str := "<session"
+	str += fmt.Sprint(" enabled=", s.Enabled())
+	str += fmt.Sprint(" empty=", s.IsEmpty())
+	return str + ">"
+}
+
+func (v ConflictType) String() string {
+	switch v {
+	case SQLITE_CHANGESET_DATA:
+		return "SQLITE_CHANGESET_DATA"
+	case SQLITE_CHANGESET_NOTFOUND:
+		return "SQLITE_CHANGESET_NOTFOUND"
+	case SQLITE_CHANGESET_CONFLICT:
+		return "SQLITE_CHANGESET_CONFLICT"
+	case SQLITE_CHANGESET_CONSTRAINT:
+		return "SQLITE_CHANGESET_CONSTRAINT"
+	case SQLITE_CHANGESET_FOREIGN_KEY:
+		return "SQLITE_CHANGESET_FOREIGN_KEY"
+	default:
+		return "[?? Invalid ConflictType value]"
+	}
+}
+
+func (v ConflictAction) String() string {
+	switch v {
+	case SQLITE_CHANGESET_OMIT:
+		return "SQLITE_CHANGESET_OMIT"
+	case SQLITE_CHANGESET_REPLACE:
+		return "SQLITE_CHANGESET_REPLACE"
+	case SQLITE_CHANGESET_ABORT:
+		return "SQLITE_CHANGESET_ABORT"
+	default:
+		return "[?? Invalid ConflictAction value]"
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// SESSION METHODS
+
+// Attach a table to the session, so that changes to the table are recorded.
+// If table is empty, then changes to all tables are recorded. Only tables with
+// a PRIMARY KEY are recorded.
+func (s *Session) Attach(table string) error {
+	var cTable *C.char
+	if table != "" {
+		cTable = C.CString(table)
+		defer C.free(unsafe.Pointer(cTable))
+	}
+	if err := SQError(C.sqlite3session_attach(s.s, cTable)); err != SQLITE_OK {
+		return newError(s.db, err, "")
+	}
+	return nil
+}
+
+// Enabled returns true if the session is recording changes
+func (s *Session) Enabled() bool {
+	return intToBool(int(C.sqlite3session_enable(s.s, -1)))
+}
+
+// SetEnabled enables or disables recording changes
+func (s *Session) SetEnabled(v bool) {
+	C.sqlite3session_enable(s.s, C.int(boolToInt(v)))
+}
+
+// Indirect returns true if changes are flagged as indirect
+func (s *Session) Indirect() bool {
+	return intToBool(int(C.sqlite3session_indirect(s.s, -1)))
+}
+
+// SetIndirect sets whether changes are flagged as indirect
+func (s *Session) SetIndirect(v bool) {
+	C.sqlite3session_indirect(s.s, C.int(boolToInt(v)))
+}
+
+// IsEmpty returns true if no changes have been recorded
+func (s *Session) IsEmpty() bool {
+	return intToBool(int(C.sqlite3session_isempty(s.s)))
+}
+
+// Changeset returns the changes recorded as a changeset
+func (s *Session) Changeset() ([]byte, error) {
+	var n C.int
+	var p unsafe.Pointer
+	if err := SQError(C.sqlite3session_changeset(s.s, &n, &p)); err != SQLITE_OK {
+		return nil, newError(s.db, err, "")
+	}
+	return changesetBytes(n, p), nil
+}
+
+// Patchset returns the changes recorded as a patchset, which is smaller than
+// a changeset as it omits the original values of updated and deleted rows.
+// A patchset cannot be inverted.
+func (s *Session) Patchset() ([]byte, error) {
+	var n C.int
+	var p unsafe.Pointer
+	if err := SQError(C.sqlite3session_patchset(s.s, &n, &p)); err != SQLITE_OK {
+		return nil, newError(s.db, err, "")
+	}
+	return changesetBytes(n, p), nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CHANGESET METHODS
+
+// InvertChangeset returns a changeset which reverses the changes
+func InvertChangeset(changeset []byte) ([]byte, error) {
+	var n C.int
+	var p unsafe.Pointer
+
+	in, nin := changesetPtr(changeset)
+	defer C.free(in)
+	if err := SQError(C.sqlite3changeset_invert(nin, in, &n, &p)); err != SQLITE_OK {
+		return nil, err
+	}
+	return changesetBytes(n, p), nil
+}
+
+// ConcatChangesets combines changesets or patchsets into a single changeset or
+// patchset. Changesets and patchsets cannot be mixed.
+func ConcatChangesets(changesets ...[]byte) ([]byte, error) {
+	var g *C.sqlite3_changegroup
+	if err := SQError(C.sqlite3changegroup_new(&g)); err != SQLITE_OK {
+		return nil, err
+	}
+	defer C.sqlite3changegroup_delete(g)
+
+	// Add changesets to the group
+	for _, changeset := range changesets {
+		in, nin := changesetPtr(changeset)
+		err := SQError(C.sqlite3changegroup_add(g, nin, in))
+		C.free(in)
+		if err != SQLITE_OK {
+			return nil, err
+		}
+	}
+
+	// Output the group
+	var n C.int
+	var p unsafe.Pointer
+	if err := SQError(C.sqlite3changegroup_output(g, &n, &p)); err != SQLITE_OK {
+		return nil, err
+	}
+	return changesetBytes(n, p), nil
+}
+
+// ApplyChangeset applies a changeset or patchset to the database. If filter is
+// not nil, then it is called for each table and changes are only applied when
+// it returns true. When a change conflicts, the conflict function is called
+// to determine the action. If conflict is nil, then conflicting changes are
+// omitted. The changes are rolled back if the conflict function returns
+// SQLITE_CHANGESET_ABORT.
+func (c *Conn) ApplyChangeset(changeset []byte, filter FilterFunc, conflict ConflictFunc) error {
+	key := cb.add(&changesetApply{filter, conflict})
+	defer cb.remove(key)
+
+	in, nin := changesetPtr(changeset)
+	defer C.free(in)
+	if err := SQError(C._sqlite3changeset_apply((*C.sqlite3)(c), nin, in, C.int(boolToInt(filter != nil)), C.uintptr_t(key))); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// ITERATOR METHODS
+
+// Op returns the table name, number of columns, operation (SQLITE_INSERT,
+// SQLITE_UPDATE or SQLITE_DELETE) and whether the change is indirect
+func (iter *ChangesetIter) Op() (string, int, SQAction, bool) {
+	var table *C.char
+	var ncols, op, indirect C.int
+	if SQError(C.sqlite3changeset_op((*C.sqlite3_changeset_iter)(iter), &table, &ncols, &op, &indirect)) != SQLITE_OK {
+		return "", 0, 0, false
+	}
+	return C.GoString(table), int(ncols), SQAction(op), intToBool(int(indirect))
+}
+
+// PrimaryKey returns a flag for each column which is true if the column is
+// part of the primary key
+func (iter *ChangesetIter) PrimaryKey() []bool {
+	var pk *C.uchar
+	var ncols C.int
+	if SQError(C.sqlite3changeset_pk((*C.sqlite3_changeset_iter)(iter), &pk, &ncols)) != SQLITE_OK {
+		return nil
+	}
+	result := make([]bool, int(ncols))
+	for i, v := range unsafe.Slice(pk, int(ncols)) {
+		result[i] = v != 0
+	}
+	return result
+}
+
+// Old returns the original value of a column for an update or delete, or nil
+// if the value is not available
+func (iter *ChangesetIter) Old(col int) *Value {
+	var v *C.sqlite3_value
+	if SQError(C.sqlite3changeset_old((*C.sqlite3_changeset_iter)(iter), C.int(col), &v)) != SQLITE_OK {
+		return nil
+	}
+	return (*Value)(v)
+}
+
+// New returns the updated value of a column for an insert or update, or nil
+// if the value is not available
+func (iter *ChangesetIter) New(col int) *Value {
+	var v *C.sqlite3_value
+	if SQError(C.sqlite3changeset_new((*C.sqlite3_changeset_iter)(iter), C.int(col), &v)) != SQLITE_OK {
+		return nil
+	}
+	return (*Value)(v)
+}
+
+// Conflict returns the conflicting value of a column in the database, when
+// the conflict type is SQLITE_CHANGESET_DATA or SQLITE_CHANGESET_CONFLICT
+func (iter *ChangesetIter) Conflict(col int) *Value {
+	var v *C.sqlite3_value
+	if SQError(C.sqlite3changeset_conflict((*C.sqlite3_changeset_iter)(iter), C.int(col), &v)) != SQLITE_OK {
+		return nil
+	}
+	return (*Value)(v)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return a copy of a changeset in C memory, which should be freed by the caller
+func changesetPtr(changeset []byte) (unsafe.Pointer, C.int) {
+	if len(changeset) == 0 {
+		return nil, 0
+	}
+	return C.CBytes(changeset), C.int(len(changeset))
+}
+
+// Return a changeset as a byte slice, and free the C memory
+func changesetBytes(n C.int, p unsafe.Pointer) []byte {
+	if p == nil {
+		return nil
+	}
+	defer C.sqlite3_free(p)
+	return C.GoBytes(p, n)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_changeset_filter
+func go_changeset_filter(userInfo unsafe.Pointer, table *C.char) (rc C.int) {
+	// Skip the table if the filter panics
+	defer func() {
+		if r := recover(); r != nil {
+			rc = 0
+		}
+	}()
+	if apply, ok := cb.get(uintptr(userInfo)).(*changesetApply); ok && apply.filter != nil {
+		return C.int(boolToInt(apply.filter(C.GoString(table))))
+	}
+	return 1
+}
+
+//export go_changeset_conflict
+func go_changeset_conflict(userInfo unsafe.Pointer, conflict C.int, iter *C.sqlite3_changeset_iter) (rc C.int) {
+	// Abort the apply if the conflict handler panics
+	defer func() {
+		if r := recover(); r != nil {
+			rc = C.int(SQLITE_CHANGESET_ABORT)
+		}
+	}()
+	if apply, ok := cb.get(uintptr(userInfo)).(*changesetApply); ok && apply.conflict != nil {
+		return C.int(apply.conflict(ConflictType(conflict), (*ChangesetIter)(iter)))
+	}
+	return C.int(SQLITE_CHANGESET_OMIT)
+}
@@ -0,0 +1,125 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Session_001(t *testing.T) {
+	assert := assert.New(t)
+	src, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer src.Close()
+	dest, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer dest.Close()
+
+	for _, db := range []*sqlite.Conn{src, dest} {
+		assert.NoError(db.Exec("CREATE TABLE test (a INTEGER PRIMARY KEY, b TEXT)"))
+	}
+
+	// Record changes
+	session, err := src.CreateSession("")
+	assert.NoError(err)
+	defer session.Close()
+	assert.NoError(session.Attach("test"))
+	assert.True(session.Enabled())
+	assert.True(session.IsEmpty())
+	assert.NoError(src.Exec("INSERT INTO test VALUES (1, 'a'), (2, 'b'), (3, 'c')"))
+	assert.False(session.IsEmpty())
+	t.Log(session)
+
+	changeset, err := session.Changeset()
+	assert.NoError(err)
+	assert.NotEmpty(changeset)
+
+	t.Run("001", func(t *testing.T) {
+		// Apply the changeset
+		assert.NoError(dest.ApplyChangeset(changeset, nil, nil))
+		assert.Equal(3, count(t, dest, "SELECT count(*) FROM test"))
+
+		// Invert the changeset and apply it
+		inverse, err := sqlite.InvertChangeset(changeset)
+		assert.NoError(err)
+		assert.NoError(dest.ApplyChangeset(inverse, nil, nil))
+		assert.Equal(0, count(t, dest, "SELECT count(*) FROM test"))
+	})
+
+	t.Run("002", func(t *testing.T) {
+		// Filter out all tables
+		assert.NoError(dest.ApplyChangeset(changeset, func(table string) bool {
+			assert.Equal("test", table)
+			return false
+		}, nil))
+		assert.Equal(0, count(t, dest, "SELECT count(*) FROM test"))
+	})
+
+	t.Run("003", func(t *testing.T) {
+		// Conflicts are replaced
+		assert.NoError(dest.Exec("INSERT INTO test VALUES (1, 'x')"))
+		conflicts := 0
+		assert.NoError(dest.ApplyChangeset(changeset, nil, func(conflict sqlite.ConflictType, iter *sqlite.ChangesetIter) sqlite.ConflictAction {
+			table, ncols, op, _ := iter.Op()
+			assert.Equal("test", table)
+			assert.Equal(2, ncols)
+			assert.Equal(sqlite.SQLITE_INSERT, op)
+			assert.Equal(sqlite.SQLITE_CHANGESET_CONFLICT, conflict)
+			assert.Equal("x", iter.Conflict(1).Text())
+			assert.Equal([]bool{true, false}, iter.PrimaryKey())
+			conflicts++
+			return sqlite.SQLITE_CHANGESET_REPLACE
+		}))
+		assert.Equal(1, conflicts)
+		assert.Equal(1, count(t, dest, "SELECT count(*) FROM test WHERE b = 'a'"))
+
+		// Aborting rolls back the changes
+		assert.NoError(dest.Exec("DELETE FROM test WHERE a > 1"))
+		assert.Error(dest.ApplyChangeset(changeset, nil, func(sqlite.ConflictType, *sqlite.ChangesetIter) sqlite.ConflictAction {
+			return sqlite.SQLITE_CHANGESET_ABORT
+		}))
+		assert.Equal(1, count(t, dest, "SELECT count(*) FROM test"))
+	})
+
+	t.Run("004", func(t *testing.T) {
+		// Concatenate patchsets
+		patchset, err := session.Patchset()
+		assert.NoError(err)
+		session2, err := src.CreateSession("main")
+		assert.NoError(err)
+		defer session2.Close()
+		assert.NoError(session2.Attach(""))
+		assert.NoError(src.Exec("INSERT INTO test VALUES (4, 'd')"))
+		patchset2, err := session2.Patchset()
+		assert.NoError(err)
+		combined, err := sqlite.ConcatChangesets(patchset, patchset2)
+		assert.NoError(err)
+
+		assert.NoError(dest.Exec("DELETE FROM test"))
+		assert.NoError(dest.ApplyChangeset(combined, nil, nil))
+		assert.Equal(4, count(t, dest, "SELECT count(*) FROM test"))
+
+		// Closing twice is harmless
+		assert.NoError(session2.Close())
+		assert.NoError(session2.Close())
+	})
+
+	t.Run("005", func(t *testing.T) {
+		// A panic in the filter skips the table
+		assert.NoError(dest.Exec("DELETE FROM test WHERE a > 1"))
+		assert.NoError(dest.ApplyChangeset(changeset, func(string) bool {
+			panic("filter")
+		}, nil))
+		assert.Equal(1, count(t, dest, "SELECT count(*) FROM test"))
+
+		// A panic in the conflict handler aborts
+		assert.Error(dest.ApplyChangeset(changeset, nil, func(sqlite.ConflictType, *sqlite.ChangesetIter) sqlite.ConflictAction {
+			panic("conflict")
+		}))
+		assert.Equal(1, count(t, dest, "SELECT count(*) FROM test"))
+	})
+}
@@ -0,0 +1,167 @@
+package sqlite
+
+import (
+	"unsafe"
+
+	// Modules
+	multierror "github.com/hashicorp/go-multierror"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Snapshot records the state of a WAL mode database at a point in time,
+// which can be used to open read transactions on other connections which
+// observe the same state
+type Snapshot C.sqlite3_snapshot
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// GetSnapshot returns a snapshot for the current read transaction on a schema.
+// The database must be in WAL mode and a read transaction must be open. The
+// snapshot should be closed when no longer required.
+func (c *Conn) GetSnapshot(schema string) (*Snapshot, error) {
+	var s *C.sqlite3_snapshot
+
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	var cSchema *C.char = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+
+	if err := SQError(C.sqlite3_snapshot_get((*C.sqlite3)(c), cSchema, &s)); err != SQLITE_OK {
+		return nil, newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return (*Snapshot)(s), nil
+}
+
+// Close releases the snapshot
+func (s *Snapshot) Close() error {
+	C.sqlite3_snapshot_free((*C.sqlite3_snapshot)(s))
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// OpenSnapshot starts a read transaction on a schema which observes the
+// state of the database when the snapshot was taken. A transaction must have
+// been started with BEGIN, but no read transaction may be open on the schema.
+func (c *Conn) OpenSnapshot(schema string, s *Snapshot) error {
+	if s == nil {
+		return ErrBadParameter.With("OpenSnapshot")
+	}
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	var cSchema *C.char = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+
+	if err := SQError(C.sqlite3_snapshot_open((*C.sqlite3)(c), cSchema, (*C.sqlite3_snapshot)(s))); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
+
+// RecoverSnapshot attempts to make snapshots available which were taken
+// before the database was last closed, by scanning the WAL file
+func (c *Conn) RecoverSnapshot(schema string) error {
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	var cSchema *C.char = C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+
+	if err := SQError(C.sqlite3_snapshot_recover((*C.sqlite3)(c), cSchema)); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
+
+// Cmp returns a negative number if the snapshot is older than other, zero
+// if they are the same and a positive number if it is newer. Snapshots can
+// only be compared if they are from the same database file and the WAL file
+// has not been reset in between.
+func (s *Snapshot) Cmp(other *Snapshot) int {
+	return int(C.sqlite3_snapshot_cmp((*C.sqlite3_snapshot)(s), (*C.sqlite3_snapshot)(other)))
+}
+
+// ReadSnapshot starts read transactions on a schema for each connection, all
+// of which observe the same state of the database, calls fn and then ends
+// the read transactions. The connections should be separate connections to
+// the same WAL mode database, with no transaction open.
+func ReadSnapshot(schema string, conns []*Conn, fn func() error) (result error) {
+	if len(conns) == 0 || fn == nil {
+		return ErrBadParameter.With("ReadSnapshot")
+	}
+	if schema == "" {
+		schema = DefaultSchema
+	}
+
+	// Read the schema on each connection, so that connections which have not
+	// yet read from the database find it is in WAL mode
+	for _, conn := range conns {
+		if err := conn.Exec("SELECT count(*) FROM " + quoteIdentifier(schema) + ".sqlite_schema"); err != nil {
+			return err
+		}
+	}
+
+	// Start a transaction on each connection, and end them on return
+	for i, conn := range conns {
+		if err := conn.Exec("BEGIN"); err != nil {
+			result = multierror.Append(result, err)
+			conns = conns[:i]
+			break
+		}
+	}
+	defer func() {
+		for _, conn := range conns {
+			if err := conn.Exec("COMMIT"); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+	}()
+	if result != nil {
+		return result
+	}
+
+	// Open a read transaction on the first connection and take a snapshot
+	if err := conns[0].Exec("SELECT count(*) FROM " + quoteIdentifier(schema) + ".sqlite_schema"); err != nil {
+		return err
+	}
+	snapshot, err := conns[0].GetSnapshot(schema)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+
+	// Open read transactions on the other connections at the same snapshot
+	for _, conn := range conns[1:] {
+		if err := conn.OpenSnapshot(schema, snapshot); err != nil {
+			return err
+		}
+	}
+
+	// Call the function
+	return fn()
+}
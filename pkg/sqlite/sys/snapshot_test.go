@@ -0,0 +1,95 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Snapshot_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Snapshots are not available for in-memory databases
+	assert.NoError(db.Exec("BEGIN"))
+	assert.NoError(db.Exec("SELECT count(*) FROM sqlite_schema"))
+	snapshot, err := db.GetSnapshot("")
+	assert.Error(err)
+	assert.Nil(snapshot)
+	assert.NoError(db.Exec("COMMIT"))
+
+	// Bad parameters
+	assert.Error(db.OpenSnapshot("", nil))
+	assert.Error(sqlite.ReadSnapshot("", nil, func() error { return nil }))
+	assert.Error(sqlite.ReadSnapshot("", []*sqlite.Conn{db}, nil))
+
+	// The transaction is ended when the snapshot cannot be taken
+	assert.Error(sqlite.ReadSnapshot("", []*sqlite.Conn{db}, func() error { return nil }))
+	assert.True(db.Autocommit())
+}
+
+func Test_Snapshot_002(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "test.sqlite")
+
+	// Create a WAL mode database with one row
+	w, err := sqlite.OpenPath(path, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer w.Close()
+	mode, err := w.SetJournalMode("", sqlite.JournalWAL)
+	assert.NoError(err)
+	assert.Equal(sqlite.JournalWAL, mode)
+	assert.NoError(w.Exec("CREATE TABLE t (a); INSERT INTO t VALUES (1)"))
+
+	// Take a snapshot on the reader
+	r, err := sqlite.OpenPath(path, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer r.Close()
+	assert.NoError(r.Exec("BEGIN"))
+	assert.Equal(1, count(t, r, "SELECT count(*) FROM t"))
+	s1, err := r.GetSnapshot("")
+	assert.NoError(err)
+	defer s1.Close()
+	assert.NoError(r.Exec("COMMIT"))
+
+	// The writer commits a second row, which the reader sees
+	assert.NoError(w.Exec("INSERT INTO t VALUES (2)"))
+	assert.Equal(2, count(t, r, "SELECT count(*) FROM t"))
+
+	// The reader still sees one row when reading from the snapshot
+	assert.NoError(r.Exec("BEGIN"))
+	assert.NoError(r.OpenSnapshot("", s1))
+	assert.Equal(1, count(t, r, "SELECT count(*) FROM t"))
+	assert.NoError(r.Exec("COMMIT"))
+
+	// A later snapshot is newer
+	assert.NoError(r.Exec("BEGIN"))
+	assert.Equal(2, count(t, r, "SELECT count(*) FROM t"))
+	s2, err := r.GetSnapshot("")
+	assert.NoError(err)
+	defer s2.Close()
+	assert.NoError(r.Exec("COMMIT"))
+	assert.Equal(0, s1.Cmp(s1))
+	assert.Less(s1.Cmp(s2), 0)
+	assert.Greater(s2.Cmp(s1), 0)
+
+	// Connections reading from a shared snapshot see the same rows, even
+	// when the writer commits in between
+	r2, err := sqlite.OpenPath(path, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer r2.Close()
+	assert.NoError(sqlite.ReadSnapshot("", []*sqlite.Conn{r, r2}, func() error {
+		assert.NoError(w.Exec("INSERT INTO t VALUES (3)"))
+		assert.Equal(2, count(t, r, "SELECT count(*) FROM t"))
+		assert.Equal(2, count(t, r2, "SELECT count(*) FROM t"))
+		return nil
+	}))
+	assert.Equal(3, count(t, r2, "SELECT count(*) FROM t"))
+}
@@ -1,9 +1,5 @@
 package sqlite
 
-import (
-	"strings"
-)
-
 ///////////////////////////////////////////////////////////////////////////////
 // CGO
 
@@ -51,12 +47,6 @@ type Conn C.sqlite3
 ///////////////////////////////////////////////////////////////////////////////
 // GLOBALS
 
-const (
-	DefaultSchema = "main"
-	DefaultMemory = ":memory:"
-	DefaultFlags  = SQLITE_OPEN_CREATE | SQLITE_OPEN_READWRITE
-)
-
 const (
 	SQLITE_OPEN_NONE         OpenFlags = 0
 	SQLITE_OPEN_READONLY     OpenFlags = C.SQLITE_OPEN_READONLY     // The database is opened in read-only mode. If the database does not already exist, an error is returned.
@@ -72,46 +62,3 @@ const (
 	SQLITE_OPEN_MIN = SQLITE_OPEN_READONLY
 	SQLITE_OPEN_MAX = SQLITE_OPEN_PRIVATECACHE
 )
-
-///////////////////////////////////////////////////////////////////////////////
-// STRINGIFY
-
-func (v OpenFlags) StringFlag() string {
-	switch v {
-	case SQLITE_OPEN_NONE:
-		return "SQLITE_OPEN_NONE"
-	case SQLITE_OPEN_READONLY:
-		return "SQLITE_OPEN_READONLY"
-	case SQLITE_OPEN_READWRITE:
-		return "SQLITE_OPEN_READWRITE"
-	case SQLITE_OPEN_CREATE:
-		return "SQLITE_OPEN_CREATE"
-	case SQLITE_OPEN_URI:
-		return "SQLITE_OPEN_URI"
-	case SQLITE_OPEN_MEMORY:
-		return "SQLITE_OPEN_MEMORY"
-	case SQLITE_OPEN_NOMUTEX:
-		return "SQLITE_OPEN_NOMUTEX"
-	case SQLITE_OPEN_FULLMUTEX:
-		return "SQLITE_OPEN_FULLMUTEX"
-	case SQLITE_OPEN_SHAREDCACHE:
-		return "SQLITE_OPEN_SHAREDCACHE"
-	case SQLITE_OPEN_PRIVATECACHE:
-		return "SQLITE_OPEN_PRIVATECACHE"
-	default:
-		return "[?? Invalid OpenFlags value]"
-	}
-}
-
-func (v OpenFlags) String() string {
-	if v == SQLITE_OPEN_NONE {
-		return v.StringFlag()
-	}
-	str := ""
-	for f := SQLITE_OPEN_MIN; f <= SQLITE_OPEN_MAX; f <<= 1 {
-		if v&f != 0 {
-			str += "|" + f.StringFlag()
-		}
-	}
-	return strings.TrimPrefix(str, "|")
-}
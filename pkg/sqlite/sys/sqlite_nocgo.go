@@ -0,0 +1,41 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type OpenFlags int32
+
+// Conn is a connection to a database. When cgo is disabled, the connection
+// uses a pure-Go port of sqlite, and should not be used by more than one
+// goroutine at a time.
+type Conn struct {
+	tls *libc.TLS
+	db  uintptr
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	SQLITE_OPEN_NONE         OpenFlags = 0
+	SQLITE_OPEN_READONLY     OpenFlags = sqlite3.SQLITE_OPEN_READONLY     // The database is opened in read-only mode. If the database does not already exist, an error is returned.
+	SQLITE_OPEN_READWRITE    OpenFlags = sqlite3.SQLITE_OPEN_READWRITE    // The database is opened for reading and writing if possible, or reading only if the file is write protected by the operating system. In either case the database must already exist, otherwise an error is returned.
+	SQLITE_OPEN_CREATE       OpenFlags = sqlite3.SQLITE_OPEN_CREATE       // The database is created if it does not already exist
+	SQLITE_OPEN_URI          OpenFlags = sqlite3.SQLITE_OPEN_URI          // The filename can be interpreted as a URI if this flag is set.
+	SQLITE_OPEN_MEMORY       OpenFlags = sqlite3.SQLITE_OPEN_MEMORY       // The database will be opened as an in-memory database. The database is named by the "filename" argument for the purposes of cache-sharing, if shared cache mode is enabled, but the "filename" is otherwise ignored.
+	SQLITE_OPEN_NOMUTEX      OpenFlags = sqlite3.SQLITE_OPEN_NOMUTEX      // The new database connection will use the "multi-thread" threading mode. This means that separate threads are allowed to use SQLite at the same time, as long as each thread is using a different database connection.
+	SQLITE_OPEN_FULLMUTEX    OpenFlags = sqlite3.SQLITE_OPEN_FULLMUTEX    // The new database connection will use the "serialized" threading mode. This means the multiple threads can safely attempt to use the same database connection at the same time. (Mutexes will block any actual concurrency, but in this mode there is no harm in trying.)
+	SQLITE_OPEN_SHAREDCACHE  OpenFlags = sqlite3.SQLITE_OPEN_SHAREDCACHE  // The database is opened shared cache enabled, overriding the default shared cache setting provided by sqlite3_enable_shared_cache().
+	SQLITE_OPEN_PRIVATECACHE OpenFlags = sqlite3.SQLITE_OPEN_PRIVATECACHE // The database is opened shared cache disabled, overriding the default shared cache setting provided by sqlite3_enable_shared_cache().
+	//	SQLITE_OPEN_NOFOLLOW     OpenFlags = sqlite3.SQLITE_OPEN_NOFOLLOW                         // The database filename is not allowed to be a symbolic link
+	SQLITE_OPEN_MIN = SQLITE_OPEN_READONLY
+	SQLITE_OPEN_MAX = SQLITE_OPEN_PRIVATECACHE
+)
@@ -0,0 +1,217 @@
+package sqlite
+
+import (
+	"math"
+	"time"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+
+static inline int _sqlite3_bind_text(sqlite3_stmt* stmt, int n, char* p, int np) {
+	return sqlite3_bind_text(stmt, n, p, np, SQLITE_TRANSIENT);
+}
+static inline int _sqlite3_bind_blob(sqlite3_stmt* stmt, int n, void* p, int np) {
+	return sqlite3_bind_blob(stmt, n, p, np, SQLITE_TRANSIENT);
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type Statement C.sqlite3_stmt
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Prepare compiles the first SQL statement in sql, and returns the statement
+// and any remaining text. The statement is nil if sql contains only comments
+// or whitespace. When the schema is locked by another connection to the same
+// shared cache, an SQLITE_LOCKED_SHAREDCACHE error is returned. Use
+// PrepareContext to wait until the lock is released.
+func (c *Conn) Prepare(sql string) (*Statement, string, error) {
+	var s *C.sqlite3_stmt
+	var tail *C.char
+
+	// Populate CStrings
+	cSql := C.CString(sql)
+	defer C.free(unsafe.Pointer(cSql))
+
+	// Call sqlite3_prepare_v2
+	if err := SQError(C.sqlite3_prepare_v2((*C.sqlite3)(c), cSql, -1, &s, &tail)); err != SQLITE_OK {
+		return nil, "", newError((*C.sqlite3)(c), err, sql)
+	}
+
+	// Determine the remaining text
+	var rest string
+	if tail != nil {
+		rest = sql[uintptr(unsafe.Pointer(tail))-uintptr(unsafe.Pointer(cSql)):]
+	}
+
+	// Return success
+	return (*Statement)(s), rest, nil
+}
+
+// Finalize the statement, which should not be used afterwards
+func (s *Statement) Finalize() error {
+	db := C.sqlite3_db_handle((*C.sqlite3_stmt)(s))
+	if err := SQError(C.sqlite3_finalize((*C.sqlite3_stmt)(s))); err != SQLITE_OK {
+		return newError(db, err, "")
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Conn returns the connection for the statement
+func (s *Statement) Conn() *Conn {
+	return (*Conn)(C.sqlite3_db_handle((*C.sqlite3_stmt)(s)))
+}
+
+// SQL returns the text used to prepare the statement
+func (s *Statement) SQL() string {
+	return C.GoString(C.sqlite3_sql((*C.sqlite3_stmt)(s)))
+}
+
+// Readonly returns true if the statement makes no direct changes to the database
+func (s *Statement) Readonly() bool {
+	return intToBool(int(C.sqlite3_stmt_readonly((*C.sqlite3_stmt)(s))))
+}
+
+// Reset the statement so it can be stepped again. Bound values are retained.
+func (s *Statement) Reset() error {
+	if err := SQError(C.sqlite3_reset((*C.sqlite3_stmt)(s))); err != SQLITE_OK {
+		return newError(C.sqlite3_db_handle((*C.sqlite3_stmt)(s)), err, s.SQL())
+	}
+	return nil
+}
+
+// ClearBindings sets all bound parameters to NULL
+func (s *Statement) ClearBindings() error {
+	if err := SQError(C.sqlite3_clear_bindings((*C.sqlite3_stmt)(s))); err != SQLITE_OK {
+		return newError(C.sqlite3_db_handle((*C.sqlite3_stmt)(s)), err, "")
+	}
+	return nil
+}
+
+// Bind binds the arguments to the statement parameters, starting with the
+// first parameter. The arguments can be nil, an integer, floating point
+// number, boolean, string, []byte, time.Time (which is bound as RFC3339 text)
+// or a *Value.
+func (s *Statement) Bind(args ...any) error {
+	if n := int(C.sqlite3_bind_parameter_count((*C.sqlite3_stmt)(s))); len(args) != n {
+		return ErrBadParameter.Withf("Bind: expected %d arguments, got %d", n, len(args))
+	}
+	for i, arg := range args {
+		if err := s.bind(i+1, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// step evaluates the statement without waiting for a shared-cache lock to be
+// released, and returns true if a row is available
+func (s *Statement) step() (bool, error) {
+	switch err := SQError(C.sqlite3_step((*C.sqlite3_stmt)(s))); err {
+	case SQLITE_ROW:
+		return true, nil
+	case SQLITE_DONE:
+		return false, nil
+	default:
+		return false, newError(C.sqlite3_db_handle((*C.sqlite3_stmt)(s)), err, s.SQL())
+	}
+}
+
+// ColumnCount returns the number of columns in each row
+func (s *Statement) ColumnCount() int {
+	return int(C.sqlite3_column_count((*C.sqlite3_stmt)(s)))
+}
+
+// ColumnName returns the name of a column, starting at zero
+func (s *Statement) ColumnName(i int) string {
+	return C.GoString(C.sqlite3_column_name((*C.sqlite3_stmt)(s), C.int(i)))
+}
+
+// Column returns the value of a column in the current row, starting at zero.
+// The value is valid until the statement is stepped, reset or finalized.
+func (s *Statement) Column(i int) *Value {
+	return (*Value)(C.sqlite3_column_value((*C.sqlite3_stmt)(s), C.int(i)))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (s *Statement) bind(i int, v any) error {
+	var err SQError
+	stmt := (*C.sqlite3_stmt)(s)
+	switch v := v.(type) {
+	case nil:
+		err = SQError(C.sqlite3_bind_null(stmt, C.int(i)))
+	case int:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case int8:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case int16:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case int32:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case int64:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case uint8:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case uint16:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case uint32:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return ErrBadParameter.Withf("Bind: integer overflow %v", v)
+		}
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case uint64:
+		if v > math.MaxInt64 {
+			return ErrBadParameter.Withf("Bind: integer overflow %v", v)
+		}
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(v)))
+	case float32:
+		err = SQError(C.sqlite3_bind_double(stmt, C.int(i), C.double(v)))
+	case float64:
+		err = SQError(C.sqlite3_bind_double(stmt, C.int(i), C.double(v)))
+	case bool:
+		err = SQError(C.sqlite3_bind_int64(stmt, C.int(i), C.sqlite3_int64(boolToInt(v))))
+	case string:
+		cStr := C.CString(v)
+		defer C.free(unsafe.Pointer(cStr))
+		err = SQError(C._sqlite3_bind_text(stmt, C.int(i), cStr, C.int(len(v))))
+	case []byte:
+		if len(v) == 0 {
+			err = SQError(C.sqlite3_bind_zeroblob(stmt, C.int(i), 0))
+		} else {
+			cBlob := C.CBytes(v)
+			defer C.free(cBlob)
+			err = SQError(C._sqlite3_bind_blob(stmt, C.int(i), cBlob, C.int(len(v))))
+		}
+	case time.Time:
+		return s.bind(i, v.Format(time.RFC3339))
+	case *Value:
+		err = SQError(C.sqlite3_bind_value(stmt, C.int(i), (*C.sqlite3_value)(v)))
+	default:
+		return ErrBadParameter.Withf("Bind: unsupported type %T", v)
+	}
+	if err != SQLITE_OK {
+		return newError(C.sqlite3_db_handle(stmt), err, "")
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	"math"
+	"time"
+
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type Statement struct {
+	conn *Conn
+	stmt uintptr
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// Destructor which causes sqlite to make a copy of bound text and blobs
+	sqliteTransient = libc.UintptrFromInt32(-1)
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// Prepare compiles the first SQL statement in sql, and returns the statement
+// and any remaining text. The statement is nil if sql contains only comments
+// or whitespace. When the schema is locked by another connection to the same
+// shared cache, an SQLITE_LOCKED_SHAREDCACHE error is returned. Use
+// PrepareContext to wait until the lock is released.
+func (c *Conn) Prepare(sql string) (*Statement, string, error) {
+	// Populate CStrings
+	cSql, err := libc.CString(sql)
+	if err != nil {
+		return nil, "", err
+	}
+	defer libc.Xfree(c.tls, cSql)
+
+	// Call sqlite3_prepare_v2, with the statement and tail returned on the TLS stack
+	pStmt := c.tls.Alloc(8)
+	defer c.tls.Free(8)
+	pTail := c.tls.Alloc(8)
+	defer c.tls.Free(8)
+	if err := SQError(sqlite3.Xsqlite3_prepare_v2(c.tls, c.db, cSql, -1, pStmt, pTail)); err != SQLITE_OK {
+		return nil, "", newError(c, err, sql)
+	}
+
+	// Determine the remaining text
+	var rest string
+	if tail := libc.AtomicLoadPUintptr(pTail); tail != 0 {
+		rest = sql[tail-cSql:]
+	}
+
+	// Return success
+	if stmt := libc.AtomicLoadPUintptr(pStmt); stmt == 0 {
+		return nil, rest, nil
+	} else {
+		return &Statement{conn: c, stmt: stmt}, rest, nil
+	}
+}
+
+// Finalize the statement, which should not be used afterwards
+func (s *Statement) Finalize() error {
+	if err := SQError(sqlite3.Xsqlite3_finalize(s.conn.tls, s.stmt)); err != SQLITE_OK {
+		return newError(s.conn, err, "")
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Conn returns the connection for the statement
+func (s *Statement) Conn() *Conn {
+	return s.conn
+}
+
+// SQL returns the text used to prepare the statement
+func (s *Statement) SQL() string {
+	return libc.GoString(sqlite3.Xsqlite3_sql(s.conn.tls, s.stmt))
+}
+
+// Readonly returns true if the statement makes no direct changes to the database
+func (s *Statement) Readonly() bool {
+	return intToBool(int(sqlite3.Xsqlite3_stmt_readonly(s.conn.tls, s.stmt)))
+}
+
+// Reset the statement so it can be stepped again. Bound values are retained.
+func (s *Statement) Reset() error {
+	if err := SQError(sqlite3.Xsqlite3_reset(s.conn.tls, s.stmt)); err != SQLITE_OK {
+		return newError(s.conn, err, s.SQL())
+	}
+	return nil
+}
+
+// ClearBindings sets all bound parameters to NULL
+func (s *Statement) ClearBindings() error {
+	if err := SQError(sqlite3.Xsqlite3_clear_bindings(s.conn.tls, s.stmt)); err != SQLITE_OK {
+		return newError(s.conn, err, "")
+	}
+	return nil
+}
+
+// Bind binds the arguments to the statement parameters, starting with the
+// first parameter. The arguments can be nil, an integer, floating point
+// number, boolean, string, []byte, time.Time (which is bound as RFC3339 text)
+// or a *Value.
+func (s *Statement) Bind(args ...any) error {
+	if n := int(sqlite3.Xsqlite3_bind_parameter_count(s.conn.tls, s.stmt)); len(args) != n {
+		return ErrBadParameter.Withf("Bind: expected %d arguments, got %d", n, len(args))
+	}
+	for i, arg := range args {
+		if err := s.bind(i+1, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// step evaluates the statement without waiting for a shared-cache lock to be
+// released, and returns true if a row is available
+func (s *Statement) step() (bool, error) {
+	switch err := SQError(sqlite3.Xsqlite3_step(s.conn.tls, s.stmt)); err {
+	case SQLITE_ROW:
+		return true, nil
+	case SQLITE_DONE:
+		return false, nil
+	default:
+		return false, newError(s.conn, err, s.SQL())
+	}
+}
+
+// ColumnCount returns the number of columns in each row
+func (s *Statement) ColumnCount() int {
+	return int(sqlite3.Xsqlite3_column_count(s.conn.tls, s.stmt))
+}
+
+// ColumnName returns the name of a column, starting at zero
+func (s *Statement) ColumnName(i int) string {
+	return libc.GoString(sqlite3.Xsqlite3_column_name(s.conn.tls, s.stmt, int32(i)))
+}
+
+// Column returns the value of a column in the current row, starting at zero.
+// The value is valid until the statement is stepped, reset or finalized.
+func (s *Statement) Column(i int) *Value {
+	return &Value{tls: s.conn.tls, v: sqlite3.Xsqlite3_column_value(s.conn.tls, s.stmt, int32(i))}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (s *Statement) bind(i int, v any) error {
+	var err SQError
+	tls, stmt := s.conn.tls, s.stmt
+	switch v := v.(type) {
+	case nil:
+		err = SQError(sqlite3.Xsqlite3_bind_null(tls, stmt, int32(i)))
+	case int:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case int8:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case int16:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case int32:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case int64:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case uint8:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case uint16:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case uint32:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return ErrBadParameter.Withf("Bind: integer overflow %v", v)
+		}
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case uint64:
+		if v > math.MaxInt64 {
+			return ErrBadParameter.Withf("Bind: integer overflow %v", v)
+		}
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(v)))
+	case float32:
+		err = SQError(sqlite3.Xsqlite3_bind_double(tls, stmt, int32(i), float64(v)))
+	case float64:
+		err = SQError(sqlite3.Xsqlite3_bind_double(tls, stmt, int32(i), v))
+	case bool:
+		err = SQError(sqlite3.Xsqlite3_bind_int64(tls, stmt, int32(i), sqlite3.Sqlite_int64(boolToInt(v))))
+	case string:
+		cStr, cErr := libc.CString(v)
+		if cErr != nil {
+			return cErr
+		}
+		defer libc.Xfree(tls, cStr)
+		err = SQError(sqlite3.Xsqlite3_bind_text(tls, stmt, int32(i), cStr, int32(len(v)), sqliteTransient))
+	case []byte:
+		if len(v) == 0 {
+			err = SQError(sqlite3.Xsqlite3_bind_zeroblob(tls, stmt, int32(i), 0))
+		} else {
+			cBlob, cErr := libc.CString(string(v))
+			if cErr != nil {
+				return cErr
+			}
+			defer libc.Xfree(tls, cBlob)
+			err = SQError(sqlite3.Xsqlite3_bind_blob(tls, stmt, int32(i), cBlob, int32(len(v)), sqliteTransient))
+		}
+	case time.Time:
+		return s.bind(i, v.Format(time.RFC3339))
+	case *Value:
+		err = SQError(sqlite3.Xsqlite3_bind_value(tls, stmt, int32(i), v.v))
+	default:
+		return ErrBadParameter.Withf("Bind: unsupported type %T", v)
+	}
+	if err != SQLITE_OK {
+		return newError(s.conn, err, "")
+	}
+	return nil
+}
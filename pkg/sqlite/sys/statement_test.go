@@ -0,0 +1,118 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Statement_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Prepare returns the remaining text
+	st, rest, err := db.Prepare("CREATE TABLE t (a, b); SELECT 1")
+	assert.NoError(err)
+	assert.Equal(" SELECT 1", rest)
+	row, err := st.Step(context.Background())
+	assert.NoError(err)
+	assert.False(row)
+	assert.NoError(st.Finalize())
+
+	// Insert with bound values
+	st, _, err = db.Prepare("INSERT INTO t VALUES (?, ?)")
+	assert.NoError(err)
+	assert.Error(st.Bind(1))
+	for _, v := range [][]any{{int64(1), "one"}, {2.5, []byte("two")}, {true, nil}} {
+		assert.NoError(st.Bind(v...))
+		_, err := st.Step(context.Background())
+		assert.NoError(err)
+		assert.NoError(st.Reset())
+	}
+	assert.NoError(st.Finalize())
+
+	// Select rows
+	st, _, err = db.Prepare("SELECT a, b FROM t ORDER BY rowid")
+	assert.NoError(err)
+	assert.True(st.Readonly())
+	assert.Equal(2, st.ColumnCount())
+	assert.Equal("a", st.ColumnName(0))
+	assert.Equal(db, st.Conn())
+	var result [][]any
+	for {
+		row, err := st.Step(context.Background())
+		assert.NoError(err)
+		if !row {
+			break
+		}
+		result = append(result, []any{st.Column(0).Interface(), st.Column(1).Interface()})
+	}
+	assert.Equal([][]any{{int64(1), "one"}, {2.5, []byte("two")}, {int64(1), nil}}, result)
+	assert.NoError(st.Finalize())
+}
+
+func Test_Statement_002(t *testing.T) {
+	assert := assert.New(t)
+	url := "file:Test_Statement_002?mode=memory&cache=shared"
+	a, err := sqlite.OpenUrl(url, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer a.Close()
+	b, err := sqlite.OpenUrl(url, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer b.Close()
+	assert.NoError(a.Exec("CREATE TABLE t (a)"))
+
+	// Lock the table from the first connection
+	assert.NoError(a.Exec("BEGIN; INSERT INTO t VALUES (1)"))
+	st, _, err := b.Prepare("SELECT count(*) FROM t")
+	assert.NoError(err)
+	defer st.Finalize()
+
+	// Stepping is cancelled by the context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = st.Step(ctx)
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+
+	// Reset returns the error from the last step
+	assert.ErrorIs(st.Reset(), sqlite.SQLITE_LOCKED_SHAREDCACHE)
+
+	// Stepping waits until the transaction is committed
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(a.Exec("COMMIT"))
+	}()
+	row, err := st.Step(context.Background())
+	assert.NoError(err)
+	assert.True(row)
+	assert.Equal(int64(1), st.Column(0).Int64())
+	assert.NoError(st.Reset())
+
+	// ExecContext waits until the transaction is committed
+	assert.NoError(a.Exec("BEGIN; INSERT INTO t VALUES (2)"))
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(a.Exec("COMMIT"))
+	}()
+	assert.ErrorIs(b.Exec("DELETE FROM t"), sqlite.SQLITE_LOCKED_SHAREDCACHE)
+	assert.NoError(b.ExecContext(context.Background(), "DELETE FROM t"))
+
+	// PrepareContext waits until the schema is unlocked
+	assert.NoError(a.Exec("BEGIN; CREATE TABLE u (a)"))
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(a.Exec("COMMIT"))
+	}()
+	_, _, err = b.Prepare("SELECT count(*) FROM u")
+	assert.ErrorIs(err, sqlite.SQLITE_LOCKED_SHAREDCACHE)
+	st2, _, err := b.PrepareContext(context.Background(), "SELECT count(*) FROM u")
+	assert.NoError(err)
+	assert.NoError(st2.Finalize())
+}
@@ -0,0 +1,141 @@
+package sqlite
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// ConnStatus contains counters for a connection. Memory sizes are in bytes.
+type ConnStatus struct {
+	LookasideUsed          int // Number of lookaside memory slots currently checked out
+	LookasideUsedHighwater int // Highest number of lookaside memory slots checked out
+	LookasideHit           int // Number of allocations satisfied from lookaside memory
+	LookasideMissSize      int // Number of allocations which were too large for lookaside memory
+	LookasideMissFull      int // Number of allocations which failed because lookaside memory was full
+	CacheUsed              int // Heap memory used by all pager caches
+	CacheUsedShared        int // Heap memory used by pager caches, shared equally between connections
+	CacheHit               int // Number of pager cache hits
+	CacheMiss              int // Number of pager cache misses
+	CacheWrite             int // Number of dirty cache entries written to disk
+	CacheSpill             int // Number of dirty cache entries written to disk mid-transaction
+	SchemaUsed             int // Heap memory used to store schemas
+	StmtUsed               int // Heap and lookaside memory used by prepared statements
+	DeferredForeignKeys    bool
+}
+
+// Status contains process-wide counters. Memory sizes are in bytes.
+type Status struct {
+	MemoryUsed                 int64 // Memory currently checked out
+	MemoryUsedHighwater        int64 // Highest memory checked out
+	MallocCount                int64 // Number of separate allocations currently checked out
+	MallocCountHighwater       int64 // Highest number of separate allocations checked out
+	MallocSizeHighwater        int64 // Largest allocation requested
+	PageCacheUsed              int64 // Number of page cache slots currently checked out
+	PageCacheUsedHighwater     int64 // Highest number of page cache slots checked out
+	PageCacheOverflow          int64 // Page cache allocations which were satisfied by the heap
+	PageCacheOverflowHighwater int64 // Highest page cache allocations satisfied by the heap
+	PageCacheSizeHighwater     int64 // Largest page cache allocation requested
+	ParserStackHighwater       int64 // Deepest parser stack
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Status returns counters for the connection. If reset is true, then the
+// highwater marks and hit/miss counters are reset after they are read.
+func (c *Conn) Status(reset bool) (ConnStatus, error) {
+	var result ConnStatus
+	var err error
+	result.LookasideUsed, result.LookasideUsedHighwater, err = c.dbstatus(C.SQLITE_DBSTATUS_LOOKASIDE_USED, reset)
+	if err != nil {
+		return result, err
+	}
+	for _, v := range []struct {
+		op    C.int
+		cur   *int
+		hiwtr *int
+	}{
+		{C.SQLITE_DBSTATUS_LOOKASIDE_HIT, nil, &result.LookasideHit},
+		{C.SQLITE_DBSTATUS_LOOKASIDE_MISS_SIZE, nil, &result.LookasideMissSize},
+		{C.SQLITE_DBSTATUS_LOOKASIDE_MISS_FULL, nil, &result.LookasideMissFull},
+		{C.SQLITE_DBSTATUS_CACHE_USED, &result.CacheUsed, nil},
+		{C.SQLITE_DBSTATUS_CACHE_USED_SHARED, &result.CacheUsedShared, nil},
+		{C.SQLITE_DBSTATUS_CACHE_HIT, &result.CacheHit, nil},
+		{C.SQLITE_DBSTATUS_CACHE_MISS, &result.CacheMiss, nil},
+		{C.SQLITE_DBSTATUS_CACHE_WRITE, &result.CacheWrite, nil},
+		{C.SQLITE_DBSTATUS_CACHE_SPILL, &result.CacheSpill, nil},
+		{C.SQLITE_DBSTATUS_SCHEMA_USED, &result.SchemaUsed, nil},
+		{C.SQLITE_DBSTATUS_STMT_USED, &result.StmtUsed, nil},
+	} {
+		cur, hiwtr, err := c.dbstatus(v.op, reset)
+		if err != nil {
+			return result, err
+		}
+		if v.cur != nil {
+			*v.cur = cur
+		}
+		if v.hiwtr != nil {
+			*v.hiwtr = hiwtr
+		}
+	}
+	if fks, _, err := c.dbstatus(C.SQLITE_DBSTATUS_DEFERRED_FKS, false); err != nil {
+		return result, err
+	} else {
+		result.DeferredForeignKeys = intToBool(fks)
+	}
+
+	// Return success
+	return result, nil
+}
+
+// GetStatus returns process-wide counters. If reset is true, then the
+// highwater marks are reset after they are read.
+func GetStatus(reset bool) (Status, error) {
+	var result Status
+	for _, v := range []struct {
+		op    C.int
+		cur   *int64
+		hiwtr *int64
+	}{
+		{C.SQLITE_STATUS_MEMORY_USED, &result.MemoryUsed, &result.MemoryUsedHighwater},
+		{C.SQLITE_STATUS_MALLOC_COUNT, &result.MallocCount, &result.MallocCountHighwater},
+		{C.SQLITE_STATUS_MALLOC_SIZE, nil, &result.MallocSizeHighwater},
+		{C.SQLITE_STATUS_PAGECACHE_USED, &result.PageCacheUsed, &result.PageCacheUsedHighwater},
+		{C.SQLITE_STATUS_PAGECACHE_OVERFLOW, &result.PageCacheOverflow, &result.PageCacheOverflowHighwater},
+		{C.SQLITE_STATUS_PAGECACHE_SIZE, nil, &result.PageCacheSizeHighwater},
+		{C.SQLITE_STATUS_PARSER_STACK, nil, &result.ParserStackHighwater},
+	} {
+		var cur, hiwtr C.sqlite3_int64
+		if err := SQError(C.sqlite3_status64(v.op, &cur, &hiwtr, C.int(boolToInt(reset)))); err != SQLITE_OK {
+			return result, err
+		}
+		if v.cur != nil {
+			*v.cur = int64(cur)
+		}
+		if v.hiwtr != nil {
+			*v.hiwtr = int64(hiwtr)
+		}
+	}
+
+	// Return success
+	return result, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+func (c *Conn) dbstatus(op C.int, reset bool) (int, int, error) {
+	var cur, hiwtr C.int
+	if err := SQError(C.sqlite3_db_status((*C.sqlite3)(c), op, &cur, &hiwtr, C.int(boolToInt(reset)))); err != SQLITE_OK {
+		return 0, 0, err
+	}
+	return int(cur), int(hiwtr), nil
+}
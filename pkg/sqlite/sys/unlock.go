@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"unsafe"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdint.h>
+
+extern void go_unlock_notify(void** apArg, int nArg);
+
+static inline int _sqlite3_unlock_notify(sqlite3* db, uintptr_t userInfo) {
+	return sqlite3_unlock_notify(db, go_unlock_notify, (void* )(userInfo));
+}
+static inline int _sqlite3_unlock_notify_cancel(sqlite3* db) {
+	return sqlite3_unlock_notify(db, NULL, NULL);
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// unlockNotify is closed when the blocking connection releases its lock
+type unlockNotify chan struct{}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// waitForUnlockNotify blocks until the connection which holds a shared-cache
+// lock has finished its transaction, or the context is cancelled. Returns
+// SQLITE_LOCKED if waiting would deadlock.
+func (c *Conn) waitForUnlockNotify(ctx context.Context) error {
+	ch := make(unlockNotify)
+	key := cb.add(ch)
+	defer cb.remove(key)
+
+	// Register the notification, which may be called immediately if the
+	// blocking connection has already finished
+	if err := SQError(C._sqlite3_unlock_notify((*C.sqlite3)(c), C.uintptr_t(key))); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Wait for notification or cancellation
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		C._sqlite3_unlock_notify_cancel((*C.sqlite3)(c))
+		return ctx.Err()
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_unlock_notify
+func go_unlock_notify(apArg *unsafe.Pointer, nArg C.int) {
+	for _, arg := range unsafe.Slice(apArg, int(nArg)) {
+		if ch, ok := cb.get(uintptr(arg)).(unlockNotify); ok {
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	"context"
+	"unsafe"
+
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// unlockNotify is closed when the blocking connection releases its lock
+type unlockNotify chan struct{}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+var (
+	// Function pointer for go_unlock_notify which can be passed to sqlite
+	xUnlockNotify = *(*uintptr)(unsafe.Pointer(&struct {
+		f func(*libc.TLS, uintptr, int32)
+	}{go_unlock_notify}))
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// waitForUnlockNotify blocks until the connection which holds a shared-cache
+// lock has finished its transaction, or the context is cancelled. Returns
+// SQLITE_LOCKED if waiting would deadlock.
+func (c *Conn) waitForUnlockNotify(ctx context.Context) error {
+	ch := make(unlockNotify)
+	key := cb.add(ch)
+	defer cb.remove(key)
+
+	// Register the notification, which may be called immediately if the
+	// blocking connection has already finished
+	if err := SQError(sqlite3.Xsqlite3_unlock_notify(c.tls, c.db, xUnlockNotify, key)); err != SQLITE_OK {
+		return newError(c, err, "")
+	}
+
+	// Wait for notification or cancellation
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		sqlite3.Xsqlite3_unlock_notify(c.tls, c.db, 0, 0)
+		return ctx.Err()
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+func go_unlock_notify(tls *libc.TLS, apArg uintptr, nArg int32) {
+	for i := uintptr(0); i < uintptr(nArg); i++ {
+		arg := libc.AtomicLoadPUintptr(apArg + i*unsafe.Sizeof(uintptr(0)))
+		if ch, ok := cb.get(arg).(unlockNotify); ok {
+			close(ch)
+		}
+	}
+}
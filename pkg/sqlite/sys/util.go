@@ -1,5 +1,9 @@
 package sqlite
 
+import (
+	"strings"
+)
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
@@ -19,3 +23,7 @@ func intToBool(v int) bool {
 	return true
 }
 
+// Quote an identifier, such as a schema name, with double quotes
+func quoteIdentifier(v string) string {
+	return "\"" + strings.ReplaceAll(v, "\"", "\"\"") + "\""
+}
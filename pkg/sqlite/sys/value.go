@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"math"
+	"time"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+
+static inline void _sqlite3_result_text(sqlite3_context* ctx, char* p, int n) {
+	sqlite3_result_text(ctx, p, n, SQLITE_TRANSIENT);
+}
+static inline void _sqlite3_result_blob(sqlite3_context* ctx, void* p, int n) {
+	sqlite3_result_blob(ctx, p, n, SQLITE_TRANSIENT);
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type (
+	Type    C.int
+	Value   C.sqlite3_value
+	Context C.sqlite3_context
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	SQLITE_INTEGER Type = C.SQLITE_INTEGER
+	SQLITE_FLOAT   Type = C.SQLITE_FLOAT
+	SQLITE_TEXT    Type = C.SQLITE_TEXT
+	SQLITE_BLOB    Type = C.SQLITE_BLOB
+	SQLITE_NULL    Type = C.SQLITE_NULL
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// VALUE METHODS
+
+// Type returns the datatype of the value
+func (v *Value) Type() Type {
+	return Type(C.sqlite3_value_type((*C.sqlite3_value)(v)))
+}
+
+// Int64 returns the value as an integer
+func (v *Value) Int64() int64 {
+	return int64(C.sqlite3_value_int64((*C.sqlite3_value)(v)))
+}
+
+// Float64 returns the value as a floating point number
+func (v *Value) Float64() float64 {
+	return float64(C.sqlite3_value_double((*C.sqlite3_value)(v)))
+}
+
+// Text returns the value as a string
+func (v *Value) Text() string {
+	p := C.sqlite3_value_text((*C.sqlite3_value)(v))
+	if p == nil {
+		return ""
+	}
+	n := C.sqlite3_value_bytes((*C.sqlite3_value)(v))
+	return C.GoStringN((*C.char)(unsafe.Pointer(p)), n)
+}
+
+// Blob returns the value as a byte slice
+func (v *Value) Blob() []byte {
+	p := C.sqlite3_value_blob((*C.sqlite3_value)(v))
+	if p == nil {
+		return nil
+	}
+	n := C.sqlite3_value_bytes((*C.sqlite3_value)(v))
+	return C.GoBytes(p, n)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CONTEXT METHODS
+
+// ResultNull sets the result of a function to NULL
+func (ctx *Context) ResultNull() {
+	C.sqlite3_result_null((*C.sqlite3_context)(ctx))
+}
+
+// ResultInt64 sets the result of a function to an integer
+func (ctx *Context) ResultInt64(v int64) {
+	C.sqlite3_result_int64((*C.sqlite3_context)(ctx), C.sqlite3_int64(v))
+}
+
+// ResultFloat64 sets the result of a function to a floating point number
+func (ctx *Context) ResultFloat64(v float64) {
+	C.sqlite3_result_double((*C.sqlite3_context)(ctx), C.double(v))
+}
+
+// ResultBool sets the result of a function to 1 for true or 0 for false
+func (ctx *Context) ResultBool(v bool) {
+	C.sqlite3_result_int((*C.sqlite3_context)(ctx), C.int(boolToInt(v)))
+}
+
+// ResultText sets the result of a function to a string
+func (ctx *Context) ResultText(v string) {
+	cStr := C.CString(v)
+	defer C.free(unsafe.Pointer(cStr))
+	C._sqlite3_result_text((*C.sqlite3_context)(ctx), cStr, C.int(len(v)))
+}
+
+// ResultBlob sets the result of a function to a byte slice
+func (ctx *Context) ResultBlob(v []byte) {
+	if len(v) == 0 {
+		C.sqlite3_result_zeroblob((*C.sqlite3_context)(ctx), 0)
+	} else {
+		C._sqlite3_result_blob((*C.sqlite3_context)(ctx), unsafe.Pointer(&v[0]), C.int(len(v)))
+	}
+}
+
+// ResultValue sets the result of a function to a copy of a value
+func (ctx *Context) ResultValue(v *Value) {
+	C.sqlite3_result_value((*C.sqlite3_context)(ctx), (*C.sqlite3_value)(v))
+}
+
+// ResultError sets the result of a function to an error
+func (ctx *Context) ResultError(err error) {
+	if err, ok := err.(SQError); ok {
+		C.sqlite3_result_error_code((*C.sqlite3_context)(ctx), C.int(err))
+		return
+	}
+	cStr := C.CString(err.Error())
+	defer C.free(unsafe.Pointer(cStr))
+	C.sqlite3_result_error((*C.sqlite3_context)(ctx), cStr, -1)
+}
+
+// Result sets the result of a function from a go value, which can be nil,
+// an integer, floating point number, boolean, string, []byte, time.Time
+// (which is set as RFC3339 text) or a *Value. Returns ErrBadParameter for
+// other types, and sets the result to NULL.
+func (ctx *Context) Result(v any) error {
+	switch v := v.(type) {
+	case nil:
+		ctx.ResultNull()
+	case int:
+		ctx.ResultInt64(int64(v))
+	case int8:
+		ctx.ResultInt64(int64(v))
+	case int16:
+		ctx.ResultInt64(int64(v))
+	case int32:
+		ctx.ResultInt64(int64(v))
+	case int64:
+		ctx.ResultInt64(v)
+	case uint8:
+		ctx.ResultInt64(int64(v))
+	case uint16:
+		ctx.ResultInt64(int64(v))
+	case uint32:
+		ctx.ResultInt64(int64(v))
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return ErrBadParameter.Withf("Result: integer overflow %v", v)
+		}
+		ctx.ResultInt64(int64(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			return ErrBadParameter.Withf("Result: integer overflow %v", v)
+		}
+		ctx.ResultInt64(int64(v))
+	case float32:
+		ctx.ResultFloat64(float64(v))
+	case float64:
+		ctx.ResultFloat64(v)
+	case bool:
+		ctx.ResultBool(v)
+	case string:
+		ctx.ResultText(v)
+	case []byte:
+		ctx.ResultBlob(v)
+	case time.Time:
+		ctx.ResultText(v.Format(time.RFC3339))
+	case *Value:
+		ctx.ResultValue(v)
+	default:
+		ctx.ResultNull()
+		return ErrBadParameter.Withf("Result: unsupported type %T", v)
+	}
+	return nil
+}
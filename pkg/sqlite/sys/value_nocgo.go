@@ -0,0 +1,70 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type Type int32
+
+// Value is a value in a row or a function argument. When cgo is disabled,
+// the value is valid while the statement which returned it is unchanged.
+type Value struct {
+	tls *libc.TLS
+	v   uintptr
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	SQLITE_INTEGER Type = sqlite3.SQLITE_INTEGER
+	SQLITE_FLOAT   Type = sqlite3.SQLITE_FLOAT
+	SQLITE_TEXT    Type = sqlite3.SQLITE_TEXT
+	SQLITE_BLOB    Type = sqlite3.SQLITE_BLOB
+	SQLITE_NULL    Type = sqlite3.SQLITE_NULL
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// VALUE METHODS
+
+// Type returns the datatype of the value
+func (v *Value) Type() Type {
+	return Type(sqlite3.Xsqlite3_value_type(v.tls, v.v))
+}
+
+// Int64 returns the value as an integer
+func (v *Value) Int64() int64 {
+	return int64(sqlite3.Xsqlite3_value_int64(v.tls, v.v))
+}
+
+// Float64 returns the value as a floating point number
+func (v *Value) Float64() float64 {
+	return sqlite3.Xsqlite3_value_double(v.tls, v.v)
+}
+
+// Text returns the value as a string
+func (v *Value) Text() string {
+	p := sqlite3.Xsqlite3_value_text(v.tls, v.v)
+	if p == 0 {
+		return ""
+	}
+	n := sqlite3.Xsqlite3_value_bytes(v.tls, v.v)
+	return string(libc.GoBytes(p, int(n)))
+}
+
+// Blob returns the value as a byte slice
+func (v *Value) Blob() []byte {
+	p := sqlite3.Xsqlite3_value_blob(v.tls, v.v)
+	if p == 0 {
+		return nil
+	}
+	n := sqlite3.Xsqlite3_value_bytes(v.tls, v.v)
+	return append([]byte{}, libc.GoBytes(p, int(n))...)
+}
@@ -0,0 +1,19 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	// Modules
+	libc "modernc.org/libc"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Return version
+func Version() (string, int, string) {
+	tls := libc.NewTLS()
+	defer tls.Close()
+	return libc.GoString(sqlite3.Xsqlite3_libversion(tls)), int(sqlite3.Xsqlite3_libversion_number(tls)), libc.GoString(sqlite3.Xsqlite3_sourceid(tls))
+}
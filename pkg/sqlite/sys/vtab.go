@@ -0,0 +1,625 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <stdint.h>
+#include <string.h>
+
+typedef struct go_vtab {
+	sqlite3_vtab base;
+	uintptr_t key;
+} go_vtab;
+
+typedef struct go_vtab_cursor {
+	sqlite3_vtab_cursor base;
+	uintptr_t key;
+} go_vtab_cursor;
+
+extern int go_vtab_connect(sqlite3* db, uintptr_t module, int argc, char** argv, uintptr_t* key, char** errmsg);
+extern int go_vtab_bestindex(uintptr_t key, sqlite3_index_info* info, char** errmsg);
+extern int go_vtab_disconnect(uintptr_t key);
+extern int go_vtab_open(uintptr_t key, uintptr_t* cursor, char** errmsg);
+extern int go_vtab_close(uintptr_t key);
+extern int go_vtab_filter(uintptr_t key, int idxNum, char* idxStr, int argc, sqlite3_value** argv, char** errmsg);
+extern int go_vtab_next(uintptr_t key, char** errmsg);
+extern int go_vtab_eof(uintptr_t key);
+extern int go_vtab_column(uintptr_t key, sqlite3_context* ctx, int col, char** errmsg);
+extern int go_vtab_rowid(uintptr_t key, sqlite3_int64* rowid, char** errmsg);
+extern void go_callback_destroy(void* userInfo);
+
+static inline char* _sqlite3_strdup(char* s) {
+	return sqlite3_mprintf("%s", s);
+}
+
+static inline void _vtab_set_error(sqlite3_vtab* vtab, char* errmsg) {
+	if (errmsg) {
+		sqlite3_free(vtab->zErrMsg);
+		vtab->zErrMsg = errmsg;
+	}
+}
+
+static int _vtab_connect(sqlite3* db, void* pAux, int argc, const char* const* argv, sqlite3_vtab** ppVTab, char** pzErr) {
+	go_vtab* vtab = (go_vtab* )sqlite3_malloc(sizeof(go_vtab));
+	if (vtab == NULL) {
+		return SQLITE_NOMEM;
+	}
+	memset(vtab, 0, sizeof(go_vtab));
+	int rc = go_vtab_connect(db, (uintptr_t)(pAux), argc, (char** )(argv), &vtab->key, pzErr);
+	if (rc != SQLITE_OK) {
+		sqlite3_free(vtab);
+		return rc;
+	}
+	*ppVTab = &vtab->base;
+	return SQLITE_OK;
+}
+
+static int _vtab_bestindex(sqlite3_vtab* vtab, sqlite3_index_info* info) {
+	char* errmsg = NULL;
+	int rc = go_vtab_bestindex(((go_vtab* )(vtab))->key, info, &errmsg);
+	_vtab_set_error(vtab, errmsg);
+	return rc;
+}
+
+static int _vtab_disconnect(sqlite3_vtab* vtab) {
+	int rc = go_vtab_disconnect(((go_vtab* )(vtab))->key);
+	sqlite3_free(vtab->zErrMsg);
+	sqlite3_free(vtab);
+	return rc;
+}
+
+static int _vtab_open(sqlite3_vtab* vtab, sqlite3_vtab_cursor** ppCursor) {
+	char* errmsg = NULL;
+	go_vtab_cursor* cursor = (go_vtab_cursor* )sqlite3_malloc(sizeof(go_vtab_cursor));
+	if (cursor == NULL) {
+		return SQLITE_NOMEM;
+	}
+	memset(cursor, 0, sizeof(go_vtab_cursor));
+	int rc = go_vtab_open(((go_vtab* )(vtab))->key, &cursor->key, &errmsg);
+	_vtab_set_error(vtab, errmsg);
+	if (rc != SQLITE_OK) {
+		sqlite3_free(cursor);
+		return rc;
+	}
+	*ppCursor = &cursor->base;
+	return SQLITE_OK;
+}
+
+static int _vtab_close(sqlite3_vtab_cursor* cursor) {
+	int rc = go_vtab_close(((go_vtab_cursor* )(cursor))->key);
+	sqlite3_free(cursor);
+	return rc;
+}
+
+static int _vtab_filter(sqlite3_vtab_cursor* cursor, int idxNum, const char* idxStr, int argc, sqlite3_value** argv) {
+	char* errmsg = NULL;
+	int rc = go_vtab_filter(((go_vtab_cursor* )(cursor))->key, idxNum, (char* )(idxStr), argc, argv, &errmsg);
+	_vtab_set_error(cursor->pVtab, errmsg);
+	return rc;
+}
+
+static int _vtab_next(sqlite3_vtab_cursor* cursor) {
+	char* errmsg = NULL;
+	int rc = go_vtab_next(((go_vtab_cursor* )(cursor))->key, &errmsg);
+	_vtab_set_error(cursor->pVtab, errmsg);
+	return rc;
+}
+
+static int _vtab_eof(sqlite3_vtab_cursor* cursor) {
+	return go_vtab_eof(((go_vtab_cursor* )(cursor))->key);
+}
+
+static int _vtab_column(sqlite3_vtab_cursor* cursor, sqlite3_context* ctx, int col) {
+	char* errmsg = NULL;
+	int rc = go_vtab_column(((go_vtab_cursor* )(cursor))->key, ctx, col, &errmsg);
+	_vtab_set_error(cursor->pVtab, errmsg);
+	return rc;
+}
+
+static int _vtab_rowid(sqlite3_vtab_cursor* cursor, sqlite3_int64* rowid) {
+	char* errmsg = NULL;
+	int rc = go_vtab_rowid(((go_vtab_cursor* )(cursor))->key, rowid, &errmsg);
+	_vtab_set_error(cursor->pVtab, errmsg);
+	return rc;
+}
+
+// Module where CREATE VIRTUAL TABLE creates a table, which is also eponymous
+static sqlite3_module _vtab_module = {
+	.iVersion = 1,
+	.xCreate = _vtab_connect,
+	.xConnect = _vtab_connect,
+	.xBestIndex = _vtab_bestindex,
+	.xDisconnect = _vtab_disconnect,
+	.xDestroy = _vtab_disconnect,
+	.xOpen = _vtab_open,
+	.xClose = _vtab_close,
+	.xFilter = _vtab_filter,
+	.xNext = _vtab_next,
+	.xEof = _vtab_eof,
+	.xColumn = _vtab_column,
+	.xRowid = _vtab_rowid,
+};
+
+// Module which can only be used as an eponymous table
+static sqlite3_module _vtab_module_eponymous = {
+	.iVersion = 1,
+	.xCreate = NULL,
+	.xConnect = _vtab_connect,
+	.xBestIndex = _vtab_bestindex,
+	.xDisconnect = _vtab_disconnect,
+	.xDestroy = _vtab_disconnect,
+	.xOpen = _vtab_open,
+	.xClose = _vtab_close,
+	.xFilter = _vtab_filter,
+	.xNext = _vtab_next,
+	.xEof = _vtab_eof,
+	.xColumn = _vtab_column,
+	.xRowid = _vtab_rowid,
+};
+
+static inline int _sqlite3_create_module(sqlite3* db, const char* name, int eponymous, uintptr_t userInfo) {
+	return sqlite3_create_module_v2(db, name, eponymous ? &_vtab_module_eponymous : &_vtab_module, (void* )(userInfo), go_callback_destroy);
+}
+static inline int _sqlite3_delete_module(sqlite3* db, const char* name) {
+	return sqlite3_create_module_v2(db, name, NULL, NULL, NULL);
+}
+static inline char* _sqlite3_index_str(const char* s, int n) {
+	char* p = (char* )sqlite3_malloc(n + 1);
+	if (p) {
+		memcpy(p, s, n);
+		p[n] = 0;
+	}
+	return p;
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// Module creates virtual tables. Connect is called with the connection,
+// schema and table name and any module arguments, and returns a CREATE TABLE
+// statement which declares the columns of the table, and the table. Columns
+// declared as HIDDEN can be used as arguments for table-valued functions.
+type Module interface {
+	Connect(c *Conn, schema, table string, args []string) (string, VTable, error)
+}
+
+// EponymousOnlyModule is implemented by modules which can only be used as a
+// table or table-valued function with the same name as the module, and which
+// cannot be created with CREATE VIRTUAL TABLE
+type EponymousOnlyModule interface {
+	Module
+
+	// Returns true if the module is eponymous-only
+	EponymousOnly() bool
+}
+
+// VTable is a virtual table instance
+type VTable interface {
+	// BestIndex is called to plan a query, and should set the output fields of
+	// the index information. It can return SQLITE_CONSTRAINT if the plan
+	// is unusable.
+	BestIndex(*IndexInfo) error
+
+	// Open returns a new cursor on the table
+	Open() (VCursor, error)
+
+	// Disconnect is called when the table is no longer used
+	Disconnect() error
+}
+
+// VCursor is a cursor on a virtual table
+type VCursor interface {
+	// Filter starts a search of the table, with the index number and string
+	// which were set by BestIndex and the constraint values for each argument
+	// index which was set
+	Filter(idxNum int, idxStr string, args []*Value) error
+
+	// Next advances the cursor to the next row
+	Next() error
+
+	// EOF returns true when there are no more rows
+	EOF() bool
+
+	// Column sets the value of a column for the current row on the context
+	Column(ctx *Context, col int) error
+
+	// RowId returns the rowid of the current row
+	RowId() (int64, error)
+
+	// Close the cursor
+	Close() error
+}
+
+// IndexOp is a constraint operator
+type IndexOp C.int
+
+// IndexInfo describes the constraints and sort order of a query on a virtual
+// table, and is used to return the query plan
+type IndexInfo struct {
+	// Inputs
+	Constraints []IndexConstraint
+	OrderBy     []IndexOrderBy
+	ColumnsUsed uint64 // Mask of columns used, with bit 63 set for columns beyond 63
+
+	// Outputs
+	IdxNum          int     // Number passed to Filter
+	IdxStr          string  // String passed to Filter
+	OrderByConsumed bool    // Output is already sorted in the ORDER BY order
+	EstimatedCost   float64 // Estimated cost of the plan
+	EstimatedRows   int64   // Estimated number of rows returned
+	Unique          bool    // Plan returns at most one row
+}
+
+// IndexConstraint is a WHERE clause constraint on a column
+type IndexConstraint struct {
+	// Inputs
+	Column int     // Column index, or -1 for the rowid
+	Op     IndexOp // Constraint operator
+	Usable bool    // Constraint can be used in this plan
+
+	// Outputs
+	ArgIndex int  // When greater than zero, the value is passed to Filter at args[ArgIndex-1]
+	Omit     bool // The virtual table guarantees the constraint so it is not checked
+}
+
+// IndexOrderBy is an ORDER BY clause term
+type IndexOrderBy struct {
+	Column int
+	Desc   bool
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	SQLITE_INDEX_CONSTRAINT_EQ        IndexOp = C.SQLITE_INDEX_CONSTRAINT_EQ
+	SQLITE_INDEX_CONSTRAINT_GT        IndexOp = C.SQLITE_INDEX_CONSTRAINT_GT
+	SQLITE_INDEX_CONSTRAINT_LE        IndexOp = C.SQLITE_INDEX_CONSTRAINT_LE
+	SQLITE_INDEX_CONSTRAINT_LT        IndexOp = C.SQLITE_INDEX_CONSTRAINT_LT
+	SQLITE_INDEX_CONSTRAINT_GE        IndexOp = C.SQLITE_INDEX_CONSTRAINT_GE
+	SQLITE_INDEX_CONSTRAINT_MATCH     IndexOp = C.SQLITE_INDEX_CONSTRAINT_MATCH
+	SQLITE_INDEX_CONSTRAINT_LIKE      IndexOp = C.SQLITE_INDEX_CONSTRAINT_LIKE
+	SQLITE_INDEX_CONSTRAINT_GLOB      IndexOp = C.SQLITE_INDEX_CONSTRAINT_GLOB
+	SQLITE_INDEX_CONSTRAINT_REGEXP    IndexOp = C.SQLITE_INDEX_CONSTRAINT_REGEXP
+	SQLITE_INDEX_CONSTRAINT_NE        IndexOp = C.SQLITE_INDEX_CONSTRAINT_NE
+	SQLITE_INDEX_CONSTRAINT_ISNOT     IndexOp = C.SQLITE_INDEX_CONSTRAINT_ISNOT
+	SQLITE_INDEX_CONSTRAINT_ISNOTNULL IndexOp = C.SQLITE_INDEX_CONSTRAINT_ISNOTNULL
+	SQLITE_INDEX_CONSTRAINT_ISNULL    IndexOp = C.SQLITE_INDEX_CONSTRAINT_ISNULL
+	SQLITE_INDEX_CONSTRAINT_IS        IndexOp = C.SQLITE_INDEX_CONSTRAINT_IS
+	SQLITE_INDEX_CONSTRAINT_LIMIT     IndexOp = C.SQLITE_INDEX_CONSTRAINT_LIMIT
+	SQLITE_INDEX_CONSTRAINT_OFFSET    IndexOp = C.SQLITE_INDEX_CONSTRAINT_OFFSET
+	SQLITE_INDEX_CONSTRAINT_FUNCTION  IndexOp = C.SQLITE_INDEX_CONSTRAINT_FUNCTION
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (v IndexOp) String() string {
+	switch v {
+	case SQLITE_INDEX_CONSTRAINT_EQ:
+		return "SQLITE_INDEX_CONSTRAINT_EQ"
+	case SQLITE_INDEX_CONSTRAINT_GT:
+		return "SQLITE_INDEX_CONSTRAINT_GT"
+	case SQLITE_INDEX_CONSTRAINT_LE:
+		return "SQLITE_INDEX_CONSTRAINT_LE"
+	case SQLITE_INDEX_CONSTRAINT_LT:
+		return "SQLITE_INDEX_CONSTRAINT_LT"
+	case SQLITE_INDEX_CONSTRAINT_GE:
+		return "SQLITE_INDEX_CONSTRAINT_GE"
+	case SQLITE_INDEX_CONSTRAINT_MATCH:
+		return "SQLITE_INDEX_CONSTRAINT_MATCH"
+	case SQLITE_INDEX_CONSTRAINT_LIKE:
+		return "SQLITE_INDEX_CONSTRAINT_LIKE"
+	case SQLITE_INDEX_CONSTRAINT_GLOB:
+		return "SQLITE_INDEX_CONSTRAINT_GLOB"
+	case SQLITE_INDEX_CONSTRAINT_REGEXP:
+		return "SQLITE_INDEX_CONSTRAINT_REGEXP"
+	case SQLITE_INDEX_CONSTRAINT_NE:
+		return "SQLITE_INDEX_CONSTRAINT_NE"
+	case SQLITE_INDEX_CONSTRAINT_ISNOT:
+		return "SQLITE_INDEX_CONSTRAINT_ISNOT"
+	case SQLITE_INDEX_CONSTRAINT_ISNOTNULL:
+		return "SQLITE_INDEX_CONSTRAINT_ISNOTNULL"
+	case SQLITE_INDEX_CONSTRAINT_ISNULL:
+		return "SQLITE_INDEX_CONSTRAINT_ISNULL"
+	case SQLITE_INDEX_CONSTRAINT_IS:
+		return "SQLITE_INDEX_CONSTRAINT_IS"
+	case SQLITE_INDEX_CONSTRAINT_LIMIT:
+		return "SQLITE_INDEX_CONSTRAINT_LIMIT"
+	case SQLITE_INDEX_CONSTRAINT_OFFSET:
+		return "SQLITE_INDEX_CONSTRAINT_OFFSET"
+	case SQLITE_INDEX_CONSTRAINT_FUNCTION:
+		return "SQLITE_INDEX_CONSTRAINT_FUNCTION"
+	default:
+		return "[?? Invalid IndexOp value]"
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// CreateModule registers a virtual table module with a name. Tables are
+// created with CREATE VIRTUAL TABLE, or the module can be used directly as an
+// eponymous table. If the module implements EponymousOnlyModule and returns
+// true, then tables cannot be created with CREATE VIRTUAL TABLE. If module is
+// nil, then the module is removed.
+func (c *Conn) CreateModule(name string, module Module) error {
+	if name == "" {
+		return ErrBadParameter.With("CreateModule")
+	}
+
+	var cName *C.char = C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	// Remove the module
+	if module == nil {
+		if err := SQError(C._sqlite3_delete_module((*C.sqlite3)(c), cName)); err != SQLITE_OK {
+			return newError((*C.sqlite3)(c), err, "")
+		}
+		return nil
+	}
+
+	// The callback is removed by go_callback_destroy when the module is
+	// replaced or removed, the connection is closed, or the module cannot
+	// be created
+	var eponymous bool
+	if module, ok := module.(EponymousOnlyModule); ok {
+		eponymous = module.EponymousOnly()
+	}
+	key := cb.add(module)
+	if err := SQError(C._sqlite3_create_module((*C.sqlite3)(c), cName, C.int(boolToInt(eponymous)), C.uintptr_t(key))); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Return a result code from an error, and set the error message
+func vtabError(err error, errmsg **C.char) C.int {
+	if err == nil {
+		return C.int(SQLITE_OK)
+	}
+	cErr := C.CString(err.Error())
+	defer C.free(unsafe.Pointer(cErr))
+	*errmsg = C._sqlite3_strdup(cErr)
+	return vtabCode(err)
+}
+
+// Recover from a panic in a callback, and return it as an error. If errmsg
+// is nil then only the result code is set
+func vtabRecover(rc *C.int, errmsg **C.char) {
+	if r := recover(); r != nil {
+		if err := fmt.Errorf("%v", r); errmsg != nil {
+			*rc = vtabError(err, errmsg)
+		} else {
+			*rc = vtabCode(err)
+		}
+	}
+}
+
+// Return a result code from an error
+func vtabCode(err error) C.int {
+	var code SQError
+	if err == nil {
+		return C.int(SQLITE_OK)
+	} else if errors.As(err, &code) {
+		return C.int(code)
+	} else {
+		return C.int(SQLITE_ERROR)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_vtab_connect
+func go_vtab_connect(db *C.sqlite3, module C.uintptr_t, argc C.int, argv **C.char, key *C.uintptr_t, errmsg **C.char) (rc C.int) {
+	defer vtabRecover(&rc, errmsg)
+	m, ok := cb.get(uintptr(module)).(Module)
+	if !ok {
+		return vtabError(ErrNotFound.With("module"), errmsg)
+	}
+
+	// Arguments are module name, schema name, table name and then any arguments
+	args := make([]string, int(argc))
+	for i, arg := range unsafe.Slice(argv, int(argc)) {
+		args[i] = C.GoString(arg)
+	}
+	if len(args) < 3 {
+		return vtabError(SQLITE_MISUSE, errmsg)
+	}
+	schema, table, err := m.Connect((*Conn)(db), args[1], args[2], args[3:])
+	if err != nil {
+		return vtabError(err, errmsg)
+	}
+
+	// Declare the table
+	cSchema := C.CString(schema)
+	defer C.free(unsafe.Pointer(cSchema))
+	if err := SQError(C.sqlite3_declare_vtab(db, cSchema)); err != SQLITE_OK {
+		table.Disconnect()
+		return vtabError(newError(db, err, ""), errmsg)
+	}
+
+	// Return success
+	*key = C.uintptr_t(cb.add(table))
+	return C.int(SQLITE_OK)
+}
+
+//export go_vtab_bestindex
+func go_vtab_bestindex(key C.uintptr_t, info *C.sqlite3_index_info, errmsg **C.char) (rc C.int) {
+	defer vtabRecover(&rc, errmsg)
+	table, ok := cb.get(uintptr(key)).(VTable)
+	if !ok {
+		return C.int(SQLITE_MISUSE)
+	}
+
+	// Set inputs
+	index := &IndexInfo{
+		Constraints:   make([]IndexConstraint, int(info.nConstraint)),
+		OrderBy:       make([]IndexOrderBy, int(info.nOrderBy)),
+		ColumnsUsed:   uint64(info.colUsed),
+		EstimatedCost: float64(info.estimatedCost),
+		EstimatedRows: int64(info.estimatedRows),
+	}
+	if info.nConstraint > 0 {
+		for i, v := range unsafe.Slice(info.aConstraint, int(info.nConstraint)) {
+			index.Constraints[i] = IndexConstraint{
+				Column: int(v.iColumn),
+				Op:     IndexOp(v.op),
+				Usable: v.usable != 0,
+			}
+		}
+	}
+	if info.nOrderBy > 0 {
+		for i, v := range unsafe.Slice(info.aOrderBy, int(info.nOrderBy)) {
+			index.OrderBy[i] = IndexOrderBy{
+				Column: int(v.iColumn),
+				Desc:   v.desc != 0,
+			}
+		}
+	}
+
+	// Plan the query
+	if err := table.BestIndex(index); err != nil {
+		return vtabError(err, errmsg)
+	}
+
+	// Set outputs
+	if info.nConstraint > 0 {
+		usage := unsafe.Slice(info.aConstraintUsage, int(info.nConstraint))
+		for i, v := range index.Constraints {
+			usage[i].argvIndex = C.int(v.ArgIndex)
+			usage[i].omit = C.uchar(boolToInt(v.Omit))
+		}
+	}
+	info.idxNum = C.int(index.IdxNum)
+	if index.IdxStr != "" {
+		cStr := C.CString(index.IdxStr)
+		defer C.free(unsafe.Pointer(cStr))
+		info.idxStr = C._sqlite3_index_str(cStr, C.int(len(index.IdxStr)))
+		info.needToFreeIdxStr = 1
+	}
+	info.orderByConsumed = C.int(boolToInt(index.OrderByConsumed))
+	info.estimatedCost = C.double(index.EstimatedCost)
+	info.estimatedRows = C.sqlite3_int64(index.EstimatedRows)
+	if index.Unique {
+		info.idxFlags |= C.SQLITE_INDEX_SCAN_UNIQUE
+	}
+
+	// Return success
+	return C.int(SQLITE_OK)
+}
+
+//export go_vtab_disconnect
+func go_vtab_disconnect(key C.uintptr_t) (rc C.int) {
+	defer vtabRecover(&rc, nil)
+	defer cb.remove(uintptr(key))
+	if table, ok := cb.get(uintptr(key)).(VTable); ok {
+		return vtabCode(table.Disconnect())
+	}
+	return C.int(SQLITE_OK)
+}
+
+//export go_vtab_open
+func go_vtab_open(key C.uintptr_t, cursor *C.uintptr_t, errmsg **C.char) (rc C.int) {
+	defer vtabRecover(&rc, errmsg)
+	table, ok := cb.get(uintptr(key)).(VTable)
+	if !ok {
+		return C.int(SQLITE_MISUSE)
+	}
+	c, err := table.Open()
+	if err != nil {
+		return vtabError(err, errmsg)
+	}
+	*cursor = C.uintptr_t(cb.add(c))
+	return C.int(SQLITE_OK)
+}
+
+//export go_vtab_close
+func go_vtab_close(key C.uintptr_t) (rc C.int) {
+	defer vtabRecover(&rc, nil)
+	defer cb.remove(uintptr(key))
+	if cursor, ok := cb.get(uintptr(key)).(VCursor); ok {
+		return vtabCode(cursor.Close())
+	}
+	return C.int(SQLITE_OK)
+}
+
+//export go_vtab_filter
+func go_vtab_filter(key C.uintptr_t, idxNum C.int, idxStr *C.char, argc C.int, argv **C.sqlite3_value, errmsg **C.char) (rc C.int) {
+	defer vtabRecover(&rc, errmsg)
+	cursor, ok := cb.get(uintptr(key)).(VCursor)
+	if !ok {
+		return C.int(SQLITE_MISUSE)
+	}
+	var str string
+	if idxStr != nil {
+		str = C.GoString(idxStr)
+	}
+	return vtabError(cursor.Filter(int(idxNum), str, funcArgs(argc, argv)), errmsg)
+}
+
+//export go_vtab_next
+func go_vtab_next(key C.uintptr_t, errmsg **C.char) (rc C.int) {
+	defer vtabRecover(&rc, errmsg)
+	cursor, ok := cb.get(uintptr(key)).(VCursor)
+	if !ok {
+		return C.int(SQLITE_MISUSE)
+	}
+	return vtabError(cursor.Next(), errmsg)
+}
+
+//export go_vtab_eof
+func go_vtab_eof(key C.uintptr_t) (rc C.int) {
+	defer vtabRecover(&rc, nil)
+	cursor, ok := cb.get(uintptr(key)).(VCursor)
+	if !ok {
+		return 1
+	}
+	return C.int(boolToInt(cursor.EOF()))
+}
+
+//export go_vtab_column
+func go_vtab_column(key C.uintptr_t, ctx *C.sqlite3_context, col C.int, errmsg **C.char) (rc C.int) {
+	defer vtabRecover(&rc, errmsg)
+	cursor, ok := cb.get(uintptr(key)).(VCursor)
+	if !ok {
+		return C.int(SQLITE_MISUSE)
+	}
+	return vtabError(cursor.Column((*Context)(ctx), int(col)), errmsg)
+}
+
+//export go_vtab_rowid
+func go_vtab_rowid(key C.uintptr_t, rowid *C.sqlite3_int64, errmsg **C.char) (rc C.int) {
+	defer vtabRecover(&rc, errmsg)
+	cursor, ok := cb.get(uintptr(key)).(VCursor)
+	if !ok {
+		return C.int(SQLITE_MISUSE)
+	}
+	id, err := cursor.RowId()
+	if err != nil {
+		return vtabError(err, errmsg)
+	}
+	*rowid = C.sqlite3_int64(id)
+	return C.int(SQLITE_OK)
+}
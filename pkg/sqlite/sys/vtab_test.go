@@ -0,0 +1,211 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// SERIES MODULE - eponymous-only table-valued function series(start, stop)
+
+type series struct{}
+type seriesCursor struct{ start, stop, value int64 }
+
+func (series) EponymousOnly() bool {
+	return true
+}
+
+func (series) Connect(c *sqlite.Conn, schema, table string, args []string) (string, sqlite.VTable, error) {
+	return "CREATE TABLE x (value INTEGER, start HIDDEN, stop HIDDEN)", series{}, nil
+}
+
+func (series) BestIndex(info *sqlite.IndexInfo) error {
+	// Both start and stop are required
+	var found int
+	for i, c := range info.Constraints {
+		if c.Column < 1 || c.Op != sqlite.SQLITE_INDEX_CONSTRAINT_EQ {
+			continue
+		}
+		if !c.Usable {
+			return sqlite.SQLITE_CONSTRAINT
+		}
+		info.Constraints[i].ArgIndex = c.Column
+		info.Constraints[i].Omit = true
+		found++
+	}
+	if found != 2 {
+		return sqlite.SQLITE_ERROR
+	}
+	info.IdxStr = "start,stop"
+	info.EstimatedCost = 1
+	return nil
+}
+
+func (series) Open() (sqlite.VCursor, error) {
+	return &seriesCursor{}, nil
+}
+
+func (series) Disconnect() error {
+	return nil
+}
+
+func (c *seriesCursor) Filter(idxNum int, idxStr string, args []*sqlite.Value) error {
+	c.start, c.stop = args[0].Int64(), args[1].Int64()
+	c.value = c.start
+	return nil
+}
+
+func (c *seriesCursor) Next() error {
+	c.value++
+	return nil
+}
+
+func (c *seriesCursor) EOF() bool {
+	return c.value > c.stop
+}
+
+func (c *seriesCursor) Column(ctx *sqlite.Context, col int) error {
+	switch col {
+	case 0:
+		ctx.ResultInt64(c.value)
+	case 1:
+		ctx.ResultInt64(c.start)
+	case 2:
+		ctx.ResultInt64(c.stop)
+	}
+	return nil
+}
+
+func (c *seriesCursor) RowId() (int64, error) {
+	return c.value, nil
+}
+
+func (c *seriesCursor) Close() error {
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// SLICE MODULE - table of strings
+
+type slice []string
+type sliceCursor struct {
+	slice
+	row int
+}
+
+func (s slice) Connect(c *sqlite.Conn, schema, table string, args []string) (string, sqlite.VTable, error) {
+	return "CREATE TABLE x (value TEXT)", append(s, args...), nil
+}
+
+func (s slice) BestIndex(info *sqlite.IndexInfo) error {
+	info.EstimatedRows = int64(len(s))
+	return nil
+}
+
+func (s slice) Open() (sqlite.VCursor, error) {
+	return &sliceCursor{slice: s}, nil
+}
+
+func (s slice) Disconnect() error {
+	return nil
+}
+
+func (c *sliceCursor) Filter(int, string, []*sqlite.Value) error {
+	c.row = 0
+	return nil
+}
+
+func (c *sliceCursor) Next() error {
+	c.row++
+	return nil
+}
+
+func (c *sliceCursor) EOF() bool {
+	return c.row >= len(c.slice)
+}
+
+func (c *sliceCursor) Column(ctx *sqlite.Context, col int) error {
+	ctx.ResultText(c.slice[c.row])
+	return nil
+}
+
+func (c *sliceCursor) RowId() (int64, error) {
+	return int64(c.row), nil
+}
+
+func (c *sliceCursor) Close() error {
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PANIC MODULE - table which panics when opened
+
+type panics struct {
+	slice
+}
+
+func (p panics) Connect(c *sqlite.Conn, schema, table string, args []string) (string, sqlite.VTable, error) {
+	return "CREATE TABLE x (value TEXT)", p, nil
+}
+
+func (panics) Open() (sqlite.VCursor, error) {
+	panic("open")
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// TESTS
+
+func Test_VTab_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Capture values returned from queries
+	captured := capture(t, db)
+
+	t.Run("Eponymous", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.CreateModule("series", series{}))
+		assert.NoError(db.Exec("SELECT capture(sum(value)) FROM series(1, 10)"))
+		assert.NoError(db.Exec("SELECT capture(value) FROM series WHERE start = 3 AND stop = 4"))
+		assert.Equal([]any{int64(55), int64(3), int64(4)}, *captured)
+
+		// Missing arguments
+		assert.Error(db.Exec("SELECT value FROM series(1)"))
+
+		// Cannot create a table from an eponymous-only module
+		assert.Error(db.Exec("CREATE VIRTUAL TABLE test USING series"))
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		*captured = nil
+		assert.NoError(db.CreateModule("slice", slice{"a", "b"}))
+		assert.NoError(db.Exec("CREATE VIRTUAL TABLE test USING slice(c, d)"))
+		assert.NoError(db.Exec("SELECT capture(group_concat(value)) FROM test"))
+		assert.NoError(db.Exec("SELECT capture(count(*)) FROM slice"))
+		assert.Equal([]any{"a,b,c,d", int64(2)}, *captured)
+
+		// The table is read-only
+		assert.Error(db.Exec("INSERT INTO test VALUES ('e')"))
+		assert.NoError(db.Exec("DROP TABLE test"))
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.NoError(db.CreateModule("panics", panics{}))
+		assert.NoError(db.Exec("CREATE VIRTUAL TABLE test USING panics"))
+		assert.ErrorContains(db.Exec("SELECT value FROM test"), "open")
+		assert.NoError(db.Exec("DROP TABLE test"))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(db.CreateModule("series", nil))
+		assert.Error(db.Exec("SELECT value FROM series(1, 10)"))
+		assert.Error(db.CreateModule("", nil))
+	})
+}
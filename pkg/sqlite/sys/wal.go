@@ -0,0 +1,289 @@
+package sqlite
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	// Import into namespace
+	. "github.com/djthorpe/go-errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// CGO
+
+/*
+#cgo CFLAGS: -Iv3.40.1
+#include <sqlite3.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+extern int go_wal_hook(void* userInfo, sqlite3* db, char* schema, int frames);
+static inline void _sqlite3_wal_hook(sqlite3* db, uintptr_t userInfo) {
+	if (userInfo == 0) {
+		sqlite3_wal_hook(db, NULL, NULL);
+	} else {
+		sqlite3_wal_hook(db, (int (*)(void*, sqlite3*, const char*, int))(go_wal_hook), (void* )(userInfo));
+	}
+}
+*/
+import "C"
+
+///////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// JournalMode is the rollback journal mode for a schema
+type JournalMode string
+
+// Synchronous is the level of syncing to disk for a schema
+type Synchronous uint
+
+// CheckpointMode determines how a WAL checkpoint interacts with readers and writers
+type CheckpointMode C.int
+
+// WalHookFunc is invoked after a transaction is committed in WAL mode, with the
+// schema and the number of frames in the WAL file
+type WalHookFunc func(schema string, frames int)
+
+///////////////////////////////////////////////////////////////////////////////
+// GLOBALS
+
+const (
+	JournalDelete   JournalMode = "delete"   // Delete the rollback journal at the end of each transaction
+	JournalTruncate JournalMode = "truncate" // Truncate the rollback journal to zero length
+	JournalPersist  JournalMode = "persist"  // Overwrite the rollback journal header with zeros
+	JournalMemory   JournalMode = "memory"   // Store the rollback journal in memory
+	JournalWAL      JournalMode = "wal"      // Use a write-ahead log instead of a rollback journal
+	JournalOff      JournalMode = "off"      // Disable the rollback journal
+)
+
+const (
+	SyncOff    Synchronous = iota // Hand off data to the operating system without syncing
+	SyncNormal                    // Sync at the most critical moments
+	SyncFull                      // Sync to ensure the database cannot be corrupted on power loss
+	SyncExtra                     // Also sync the directory when the rollback journal is removed
+)
+
+const (
+	SQLITE_CHECKPOINT_PASSIVE  CheckpointMode = C.SQLITE_CHECKPOINT_PASSIVE  // Checkpoint as many frames as possible without waiting
+	SQLITE_CHECKPOINT_FULL     CheckpointMode = C.SQLITE_CHECKPOINT_FULL     // Wait for writers, then checkpoint all frames
+	SQLITE_CHECKPOINT_RESTART  CheckpointMode = C.SQLITE_CHECKPOINT_RESTART  // As FULL, then wait for readers so the WAL file restarts
+	SQLITE_CHECKPOINT_TRUNCATE CheckpointMode = C.SQLITE_CHECKPOINT_TRUNCATE // As RESTART, then truncate the WAL file to zero bytes
+)
+
+// Keys for WAL hooks registered on each connection
+var walhooks = struct {
+	sync.Mutex
+	m map[*Conn]uintptr
+}{m: make(map[*Conn]uintptr)}
+
+///////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (v Synchronous) String() string {
+	switch v {
+	case SyncOff:
+		return "SyncOff"
+	case SyncNormal:
+		return "SyncNormal"
+	case SyncFull:
+		return "SyncFull"
+	case SyncExtra:
+		return "SyncExtra"
+	default:
+		return "[?? Invalid Synchronous value]"
+	}
+}
+
+func (v CheckpointMode) String() string {
+	switch v {
+	case SQLITE_CHECKPOINT_PASSIVE:
+		return "SQLITE_CHECKPOINT_PASSIVE"
+	case SQLITE_CHECKPOINT_FULL:
+		return "SQLITE_CHECKPOINT_FULL"
+	case SQLITE_CHECKPOINT_RESTART:
+		return "SQLITE_CHECKPOINT_RESTART"
+	case SQLITE_CHECKPOINT_TRUNCATE:
+		return "SQLITE_CHECKPOINT_TRUNCATE"
+	default:
+		return "[?? Invalid CheckpointMode value]"
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// JournalMode returns the journal mode for a schema. If the schema is empty,
+// the main schema is used.
+func (c *Conn) JournalMode(schema string) (JournalMode, error) {
+	if v, err := c.pragma(schema, "journal_mode", ""); err != nil {
+		return "", err
+	} else {
+		return JournalMode(strings.ToLower(v)), nil
+	}
+}
+
+// SetJournalMode sets the journal mode for a schema, and returns the new
+// journal mode, which may be different from the one requested. For example,
+// in-memory databases cannot use JournalWAL. If the schema is empty, the main
+// schema is used.
+func (c *Conn) SetJournalMode(schema string, mode JournalMode) (JournalMode, error) {
+	switch mode {
+	case JournalDelete, JournalTruncate, JournalPersist, JournalMemory, JournalWAL, JournalOff:
+		break
+	default:
+		return "", ErrBadParameter.Withf("SetJournalMode: %q", mode)
+	}
+	if v, err := c.pragma(schema, "journal_mode", string(mode)); err != nil {
+		return "", err
+	} else {
+		return JournalMode(strings.ToLower(v)), nil
+	}
+}
+
+// Synchronous returns the synchronous level for a schema. If the schema is
+// empty, the main schema is used.
+func (c *Conn) Synchronous(schema string) (Synchronous, error) {
+	if v, err := c.pragma(schema, "synchronous", ""); err != nil {
+		return 0, err
+	} else if v, err := strconv.ParseUint(v, 10, 32); err != nil {
+		return 0, ErrUnexpectedResponse.With("Synchronous: ", err)
+	} else {
+		return Synchronous(v), nil
+	}
+}
+
+// SetSynchronous sets the synchronous level for a schema. If the schema is
+// empty, the main schema is used.
+func (c *Conn) SetSynchronous(schema string, v Synchronous) error {
+	if v > SyncExtra {
+		return ErrBadParameter.Withf("SetSynchronous: %v", v)
+	}
+	_, err := c.pragma(schema, "synchronous", strconv.FormatUint(uint64(v), 10))
+	return err
+}
+
+// WalAutoCheckpoint returns the number of frames in the WAL file which
+// trigger an automatic checkpoint, or zero if automatic checkpoints are disabled
+func (c *Conn) WalAutoCheckpoint() (int, error) {
+	if v, err := c.pragma("", "wal_autocheckpoint", ""); err != nil {
+		return 0, err
+	} else if v, err := strconv.Atoi(v); err != nil {
+		return 0, ErrUnexpectedResponse.With("WalAutoCheckpoint: ", err)
+	} else {
+		return v, nil
+	}
+}
+
+// SetWalAutoCheckpoint sets the number of frames in the WAL file which trigger
+// an automatic checkpoint for all schemas, or disables automatic checkpoints
+// when zero. Automatic checkpoints replace any WAL hook.
+func (c *Conn) SetWalAutoCheckpoint(frames int) error {
+	if frames < 0 {
+		return ErrBadParameter.With("SetWalAutoCheckpoint")
+	}
+
+	walhooks.Lock()
+	defer walhooks.Unlock()
+
+	if err := SQError(C.sqlite3_wal_autocheckpoint((*C.sqlite3)(c), C.int(frames))); err != SQLITE_OK {
+		return newError((*C.sqlite3)(c), err, "")
+	}
+	if prev, exists := walhooks.m[c]; exists {
+		cb.remove(prev)
+		delete(walhooks.m, c)
+	}
+
+	// Return success
+	return nil
+}
+
+// WalCheckpoint copies frames from the WAL file into the database for a
+// schema, or all attached schemas if the schema is empty. It returns the
+// number of frames in the WAL file and the number of frames which have been
+// copied into the database, which are both -1 if the schema is not in WAL mode.
+func (c *Conn) WalCheckpoint(schema string, mode CheckpointMode) (int, int, error) {
+	var cSchema *C.char
+	var nLog, nCkpt C.int
+
+	// Populate CStrings
+	if schema != "" {
+		cSchema = C.CString(schema)
+		defer C.free(unsafe.Pointer(cSchema))
+	}
+
+	// Call sqlite3_wal_checkpoint_v2
+	if err := SQError(C.sqlite3_wal_checkpoint_v2((*C.sqlite3)(c), cSchema, C.int(mode), &nLog, &nCkpt)); err != SQLITE_OK {
+		return int(nLog), int(nCkpt), newError((*C.sqlite3)(c), err, "")
+	}
+
+	// Return success
+	return int(nLog), int(nCkpt), nil
+}
+
+// SetWalHook sets a function which is invoked after each transaction is
+// committed in WAL mode. Any previous hook is replaced, and automatic
+// checkpoints are disabled. If fn is nil, then the hook is removed, and
+// SetWalAutoCheckpoint can be used to enable automatic checkpoints again.
+func (c *Conn) SetWalHook(fn WalHookFunc) {
+	walhooks.Lock()
+	defer walhooks.Unlock()
+
+	var key uintptr
+	if fn != nil {
+		key = cb.add(fn)
+	}
+	C._sqlite3_wal_hook((*C.sqlite3)(c), C.uintptr_t(key))
+	if prev, exists := walhooks.m[c]; exists {
+		cb.remove(prev)
+		delete(walhooks.m, c)
+	}
+	if key != 0 {
+		walhooks.m[c] = key
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// pragma returns the value of a pragma for a schema, or sets the value if
+// value is not empty, and returns the first column of the first row
+func (c *Conn) pragma(schema, name, value string) (string, error) {
+	if schema == "" {
+		schema = DefaultSchema
+	}
+	sql := "PRAGMA " + quoteIdentifier(schema) + "." + name
+	if value != "" {
+		sql += " = " + value
+	}
+	st, _, err := c.Prepare(sql)
+	if err != nil {
+		return "", err
+	}
+	defer st.Finalize()
+	if row, err := st.step(); err != nil {
+		return "", err
+	} else if !row {
+		return "", nil
+	} else {
+		return st.Column(0).Text(), nil
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// CALLBACKS
+
+//export go_wal_hook
+func go_wal_hook(userInfo unsafe.Pointer, db *C.sqlite3, schema *C.char, frames C.int) (rc C.int) {
+	// Ignore panics, as the commit has already succeeded
+	defer func() {
+		if r := recover(); r != nil {
+			rc = C.int(SQLITE_OK)
+		}
+	}()
+	if fn, ok := cb.get(uintptr(userInfo)).(WalHookFunc); ok {
+		fn(C.GoString(schema), int(frames))
+	}
+	return C.int(SQLITE_OK)
+}
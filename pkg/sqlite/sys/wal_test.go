@@ -0,0 +1,95 @@
+//go:build cgo
+
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	// Packages
+	sqlite "github.com/mutablelogic/go-accessory/pkg/sqlite/sys"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_Wal_001(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(filepath.Join(t.TempDir(), "test.sqlite"), sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// Journal mode
+	mode, err := db.JournalMode("")
+	assert.NoError(err)
+	assert.Equal(sqlite.JournalDelete, mode)
+	_, err = db.SetJournalMode("", "invalid")
+	assert.Error(err)
+	mode, err = db.SetJournalMode("", sqlite.JournalWAL)
+	assert.NoError(err)
+	assert.Equal(sqlite.JournalWAL, mode)
+
+	// Synchronous
+	assert.NoError(db.SetSynchronous("", sqlite.SyncNormal))
+	sync, err := db.Synchronous("")
+	assert.NoError(err)
+	assert.Equal(sqlite.SyncNormal, sync)
+	assert.Error(db.SetSynchronous("", sqlite.SyncExtra+1))
+
+	// Automatic checkpoints
+	assert.NoError(db.SetWalAutoCheckpoint(0))
+	frames, err := db.WalAutoCheckpoint()
+	assert.NoError(err)
+	assert.Equal(0, frames)
+
+	// WAL hook is invoked on commit
+	var hooked []string
+	db.SetWalHook(func(schema string, frames int) {
+		assert.Greater(frames, 0)
+		hooked = append(hooked, schema)
+	})
+	assert.NoError(db.Exec("CREATE TABLE t (a); INSERT INTO t VALUES (1)"))
+	assert.Equal([]string{"main", "main"}, hooked)
+
+	// A panic in the WAL hook does not fail the commit
+	db.SetWalHook(func(string, int) {
+		panic("wal")
+	})
+	assert.NoError(db.Exec("INSERT INTO t VALUES (1)"))
+
+	// Checkpoint and truncate the WAL file
+	log, ckpt, err := db.WalCheckpoint("", sqlite.SQLITE_CHECKPOINT_PASSIVE)
+	assert.NoError(err)
+	assert.Greater(log, 0)
+	assert.Equal(log, ckpt)
+	log, ckpt, err = db.WalCheckpoint(sqlite.DefaultSchema, sqlite.SQLITE_CHECKPOINT_TRUNCATE)
+	assert.NoError(err)
+	assert.Equal(0, log)
+	assert.Equal(0, ckpt)
+	_, _, err = db.WalCheckpoint("other", sqlite.SQLITE_CHECKPOINT_PASSIVE)
+	assert.Error(err)
+
+	// Automatic checkpoints replace the hook
+	assert.NoError(db.SetWalAutoCheckpoint(1000))
+	assert.NoError(db.Exec("INSERT INTO t VALUES (2)"))
+	assert.Len(hooked, 2)
+	frames, err = db.WalAutoCheckpoint()
+	assert.NoError(err)
+	assert.Equal(1000, frames)
+}
+
+func Test_Wal_002(t *testing.T) {
+	assert := assert.New(t)
+	db, err := sqlite.OpenPath(sqlite.DefaultMemory, sqlite.SQLITE_OPEN_CREATE, "")
+	assert.NoError(err)
+	defer db.Close()
+
+	// In-memory databases cannot use WAL mode
+	mode, err := db.SetJournalMode("", sqlite.JournalWAL)
+	assert.NoError(err)
+	assert.Equal(sqlite.JournalMemory, mode)
+
+	// Checkpoint is a no-op when not in WAL mode
+	log, ckpt, err := db.WalCheckpoint("", sqlite.SQLITE_CHECKPOINT_FULL)
+	assert.NoError(err)
+	assert.Equal(-1, log)
+	assert.Equal(-1, ckpt)
+}
@@ -26,6 +26,11 @@ const (
 	OpUpsert
 	OpUpsertMany
 	OpFindUpdate
+	OpListCollections
+	OpCreateCollection
+	OpDropCollection
+	OpDropDatabase
+	OpSearch
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -63,6 +68,16 @@ func (o Op) String() string {
 		return "Commit"
 	case OpRollback:
 		return "Rollback"
+	case OpListCollections:
+		return "ListCollections"
+	case OpCreateCollection:
+		return "CreateCollection"
+	case OpDropCollection:
+		return "DropCollection"
+	case OpDropDatabase:
+		return "DropDatabase"
+	case OpSearch:
+		return "Search"
 	default:
 		return "[?? Invalid Operation value]"
 	}
@@ -24,9 +24,10 @@ const (
 	IF_NOT_EXISTS
 	STRICT
 	WITHOUT_ROWID
+	DISTINCT
 	NONE           QueryFlag = 0
 	QUERY_MIN                = NOT_NULL
-	QUERY_MAX                = WITHOUT_ROWID
+	QUERY_MAX                = DISTINCT
 	QUERY_CONFLICT           = ON_CONFLICT_ROLLBACK | ON_CONFLICT_ABORT | ON_CONFLICT_FAIL | ON_CONFLICT_IGNORE | ON_CONFLICT_REPLACE
 	QUERY_SORT               = ASC | DESC
 )
@@ -62,9 +63,17 @@ type Name interface {
 	// Set a declared type
 	WithType(string) Name
 
+	// Set a collating sequence, for column definitions and sort clauses
+	WithCollation(string) Name
+
 	// Transform into a CreateTable query with columns. Use TEMPORARY, IF_NOT_EXISTS, STRICT
 	// and WITHOUT_ROWID flags to modify the table creation.
 	CreateTable(...Name) CreateTable
+
+	// Transform into a full-text search table with columns. Use WithType("UNINDEXED")
+	// for columns which are stored but not indexed, and the IF_NOT_EXISTS flag to
+	// modify the table creation.
+	FTS5(...Name) FTS5
 }
 
 type CreateTable interface {
@@ -74,6 +83,74 @@ type CreateTable interface {
 	//WithKey(QueryFlag, ...string) CreateTable
 }
 
+// FTS5 represents a full-text search table
+type FTS5 interface {
+	Query
+
+	// Use an external content table, with the column which is used as the rowid
+	WithContent(Name, string) FTS5
+
+	// Set the tokenizer, for example "porter unicode61"
+	WithTokenizer(string) FTS5
+
+	// Return the triggers which keep the index synchronised with the
+	// external content table, or nil if there is no external content table
+	Triggers() []Query
+
+	// Return an expression which matches rows with a full-text query
+	Match(string) Query
+
+	// Return the bm25() rank expression, with optional weights for each column.
+	// Lower values are better matches.
+	Rank(...float64) Query
+
+	// Return an expression which highlights matches in a column with text
+	// before and after each match
+	Highlight(int, string, string) Query
+
+	// Return an expression which returns a fragment of text from a column,
+	// with text before and after each match, an ellipsis and the maximum
+	// number of tokens
+	Snippet(int, string, string, string, int) Query
+
+	// Return a query which returns the rowid and columns of rows which match
+	// a full-text query, ordered by rank. For an external content table, all
+	// columns from the content table are returned. Additional expressions
+	// can be returned, for example Highlight and Snippet.
+	Search(string, ...Query) Query
+}
+
+// Select represents a SELECT statement. Each clause replaces any previous
+// clause of the same kind. Use the DISTINCT flag to return unique rows.
+type Select interface {
+	Query
+
+	// Return unique rows, which is the same as setting the DISTINCT flag
+	Distinct() Select
+
+	// Set the table sources, which are table names or names with an alias
+	From(...any) Select
+
+	// Set the expressions which rows must match, which are combined with AND
+	Where(...Query) Select
+
+	// Set the columns or expressions which rows are grouped by
+	GroupBy(...any) Select
+
+	// Set the expressions which groups must match, which are combined with AND
+	Having(...Query) Select
+
+	// Set the sort order, which are columns or expressions. Use names
+	// with the ASC or DESC flags to set the direction.
+	OrderBy(...any) Select
+
+	// Set the maximum number of rows returned, or zero for no limit
+	Limit(uint) Select
+
+	// Set the number of rows which are skipped
+	Offset(uint) Select
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBLIC METHODS
 
@@ -123,6 +200,8 @@ func (v QueryFlag) FlagString() string {
 		return "ON CONFLICT REPLACE"
 	case AUTO_INCREMENT:
 		return "AUTOINCREMENT"
+	case DISTINCT:
+		return "DISTINCT"
 	default:
 		return "[?? Invalid QueryFlag value]"
 	}